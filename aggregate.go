@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+	"github.com/jfkonecn/data-transport-phenomena/history"
+	"github.com/jfkonecn/data-transport-phenomena/report"
+	"github.com/jfkonecn/data-transport-phenomena/report/xlsx"
+)
+
+// OutputOptions selects the files written for an aggregation
+type OutputOptions struct {
+	// Paths maps each selected output format to its file or directory
+	Paths        map[string]string
+	LogScale     bool
+	CrossoverCSV string
+}
+
+// outputFlags registers the output flags shared by the aggregate and export
+// commands on fs, with defaultFormats selected when -formats is not given
+func outputFlags(fs *flag.FlagSet, defaultFormats string) func() (OutputOptions, error) {
+	outputPath := fs.String("output", "aggregate_data.xlsx", "workbook `file`; other formats replace its extension")
+	formatsFlag := fs.String("formats", defaultFormats, "comma separated `list` of output formats")
+	logScale := fs.Bool("log-scale", false, "use a logarithmic value axis on the algorithm comparison chart")
+	crossoverCSV := fs.String("crossovers-csv", "", "also write the crossover table to this CSV `file`")
+
+	return func() (OutputOptions, error) {
+		formats, err := parseFormats(*formatsFlag)
+		if err != nil {
+			return OutputOptions{}, err
+		}
+		return OutputOptions{Paths: outputPaths(*outputPath, formats), LogScale: *logScale, CrossoverCSV: *crossoverCSV}, nil
+	}
+}
+
+// runAggregate implements the aggregate command, the default when no command
+// is given, and returns the process exit code
+func runAggregate(args []string) int {
+	return runOutputCommand("aggregate", args, "[aggregate] [options]",
+		"Builds the workbook and any other selected outputs. With -history, the\naggregation is also recorded in that archive.",
+		"xlsx", true)
+}
+
+// runOutputCommand loads, analyses and writes the results for the aggregate
+// and export commands. The aggregation is appended to the history archive
+// when record is set.
+func runOutputCommand(name string, args []string, synopsis, description, defaultFormats string, record bool) int {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	pipeline := pipelineFlags(fs)
+	outputOptions := outputFlags(fs, defaultFormats)
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, synopsis, description, formatsSection())
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err)
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Unexpected argument: %s\nUse --help to see available options.\n", fs.Arg(0))
+		return 2
+	}
+
+	dir, options, err := pipeline()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	outputs, err := outputOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	results, err := dir.Load()
+	if err != nil {
+		log.Printf("Error loading results: %v", err)
+		return 1
+	}
+	aggregation := analysis.Analyse(results, options)
+
+	if err := writeOutputs(aggregation, outputs); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	// Record the aggregation in the history archive
+	if record && options.HistoryPath != "" {
+		if err := history.Append(options.HistoryPath, aggregation.Record); err != nil {
+			log.Printf("Warning: could not record history: %v", err)
+		}
+	}
+
+	printOutputs(outputs)
+	return 0
+}
+
+// writeOutputs writes every selected output format of an aggregation
+func writeOutputs(aggregation analysis.Aggregation, outputs OutputOptions) error {
+	for format, path := range outputs.Paths {
+		if format == "svg" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("error creating output directory: %w", err)
+		}
+	}
+
+	reporters := map[string]report.Reporter{
+		"xlsx":     xlsx.Workbook{LogScale: outputs.LogScale},
+		"json":     report.JSON{},
+		"markdown": report.Markdown{},
+		"html":     report.HTML{},
+		"svg":      report.SVG{},
+	}
+	for _, format := range outputFormats {
+		path, ok := outputs.Paths[format]
+		if !ok {
+			continue
+		}
+		if err := reporters[format].Write(path, aggregation); err != nil {
+			return fmt.Errorf("error writing %s output: %w", format, err)
+		}
+	}
+
+	if outputs.CrossoverCSV != "" {
+		if err := (report.CrossoverCSV{}).Write(outputs.CrossoverCSV, aggregation); err != nil {
+			return fmt.Errorf("error writing crossover CSV: %w", err)
+		}
+	}
+	return nil
+}
+
+// printOutputs lists the files written, in format order
+func printOutputs(outputs OutputOptions) {
+	for _, format := range outputFormats {
+		path, ok := outputs.Paths[format]
+		switch {
+		case !ok:
+		case format == "xlsx":
+			fmt.Printf("Excel file '%s' created successfully!\n", path)
+		default:
+			fmt.Printf("Wrote %s output to '%s'\n", format, path)
+		}
+	}
+}
+
+// flagExitCode maps a parseFlags error to an exit code: asking for help
+// succeeds and anything else is a usage error
+func flagExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	return 2
+}
@@ -0,0 +1,122 @@
+// Package analysis runs the comparisons and diagnostics over loaded results
+// and assembles the report every output format is written from.
+package analysis
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/history"
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Options configures the analysis run over loaded results
+type Options struct {
+	Unit           stats.CPUUnit
+	Alpha          float64
+	ClockDrift     float64
+	BaselineRun    string
+	PredictSizes   []int
+	HistoryPath    string
+	TrendThreshold float64
+}
+
+// Aggregation is everything the output writers need: the report, plus the
+// history and settings behind sheets that are not part of the JSON schema
+type Aggregation struct {
+	Report   Report
+	Options  Options
+	RunNames []string
+	// Record is this aggregation as it would be stored in the history archive
+	Record history.Record
+	// History is the archive plus Record, oldest first, when trends are enabled
+	History []history.Record
+	Trends  []history.TrendSeries
+}
+
+// Analyse runs every analysis over the loaded results and logs the warnings
+// it finds
+func Analyse(results loader.Results, opts Options) Aggregation {
+	// Check that clock estimates agree within each run name
+	clockDiagnostics := stats.CheckClockEstimates(results.CPU, opts.ClockDrift)
+	for _, diagnostic := range clockDiagnostics {
+		for _, issue := range diagnostic.Issues {
+			log.Printf("Warning: clock estimates for run %s: %s", diagnostic.RunName, issue)
+		}
+	}
+
+	// Compare run names, such as different machines, against a baseline
+	runs := stats.RunNames(results.CPU)
+	baseline := opts.BaselineRun
+	if baseline == "" && len(runs) > 0 {
+		baseline = runs[0]
+	}
+
+	// Check memory traces for leaks and imbalances
+	memoryIntegrity := stats.CheckMemoryIntegrity(results.Memory)
+	for _, integrity := range memoryIntegrity {
+		if len(integrity.Issues) > 0 {
+			log.Printf("Warning: memory trace %s_%s_%s: %s", integrity.Algorithm, integrity.RunName, integrity.File, strings.Join(integrity.Issues, "; "))
+		}
+	}
+
+	// Fit complexity models across input sizes
+	complexityFits := stats.FitCPUComplexity(results.CPU, opts.PredictSizes)
+	complexityFits = append(complexityFits, stats.FitMemoryComplexity(results.Memory, opts.PredictSizes)...)
+
+	hostname, _ := os.Hostname()
+	metadata := history.RunMetadata{
+		CPUDir:        results.CPUDir,
+		MemoryDir:     results.MemoryDir,
+		Hostname:      hostname,
+		OutlierPolicy: results.Policy,
+		Bootstrap:     results.Bootstrap,
+	}
+	record := history.NewRecord(metadata, results.CPU, results.Memory)
+
+	aggregation := Aggregation{
+		Report: Report{
+			GeneratedAt: record.Timestamp,
+			GitCommit:   record.GitCommit,
+			Metadata:    metadata,
+			Unit:        opts.Unit.Name,
+			CPUStats:    results.CPU,
+			MemoryStats: results.Memory,
+			Diagnostics: Diagnostics{
+				Clock:           clockDiagnostics,
+				MemoryIntegrity: memoryIntegrity,
+			},
+			Analysis: Findings{
+				Significance:  stats.ComparePairwise(results.CPU, opts.Alpha),
+				RunComparison: stats.CompareRuns(results.CPU, opts.Unit, baseline),
+				BaselineRun:   baseline,
+				Crossovers:    stats.FindCrossovers(results.CPU),
+				Complexity:    complexityFits,
+			},
+		},
+		Options:  opts,
+		RunNames: runs,
+		Record:   record,
+	}
+
+	// Analyse trends across the history archive, including this aggregation
+	if opts.HistoryPath != "" {
+		records, err := history.Read(opts.HistoryPath)
+		if err != nil {
+			log.Printf("Warning: could not read history: %v", err)
+		}
+		aggregation.History = append(records, record)
+		aggregation.Trends = history.BuildTrendSeries(aggregation.History)
+
+		changes := history.FindChangePoints(aggregation.History, aggregation.Trends, opts.TrendThreshold)
+		for _, c := range changes {
+			log.Printf("Change point: %s_%s_%s shifted %+.1f%% at %s (%s)", c.Algorithm, c.RunName, c.File, c.ShiftChange*100, c.Timestamp.Format(time.RFC3339), history.ShortCommit(c.GitCommit))
+		}
+		aggregation.Report.Diagnostics.ChangePoints = changes
+	}
+
+	return aggregation
+}
@@ -0,0 +1,213 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Outcomes of comparing a candidate result against its baseline
+const (
+	OutcomeImproved  = "improved"
+	OutcomeRegressed = "regressed"
+	OutcomeUnchanged = "unchanged"
+)
+
+// Regression classifies one metric of an algorithm and input file
+type Regression struct {
+	Kind          string
+	Metric        string
+	Algorithm     string
+	RunName       string
+	File          string
+	FileSizeBytes int
+	Baseline      float64
+	Candidate     float64
+	Change        float64
+	Tested        bool
+	PValue        float64
+	Outcome       string
+}
+
+// Sides of a comparison, naming where an unmatched benchmark was found
+const (
+	SideBaseline  = "baseline"
+	SideCandidate = "candidate"
+)
+
+// Unmatched is a benchmark found on only one side of a comparison
+type Unmatched struct {
+	Side          string
+	Kind          string
+	Algorithm     string
+	RunName       string
+	File          string
+	FileSizeBytes int
+}
+
+// Comparison holds the outcome of comparing two result sets
+type Comparison struct {
+	Regressions []Regression
+	// Matched counts the benchmarks found on both sides
+	Matched   int
+	Unmatched []Unmatched
+}
+
+// CompareResults matches results by algorithm, run name and input file, or
+// by algorithm and input file alone when acrossRuns is set, as when one run
+// name is compared against another. CPU medians must move by more than
+// threshold and pass a Mann-Whitney test at alpha; memory traces are single
+// runs, so only the threshold applies, and are skipped unless both traces
+// are complete. Benchmarks found on only one side are listed as unmatched.
+func CompareResults(baseline, candidate loader.Results, threshold, alpha float64, acrossRuns bool) Comparison {
+	var comparison Comparison
+	matchKey := func(algorithm, runName, file string) string {
+		if acrossRuns {
+			return algorithm + "_" + file
+		}
+		return algorithm + "_" + runName + "_" + file
+	}
+	unmatched := func(side, kind, algorithm, runName, file string, fileSizeBytes int) {
+		comparison.Unmatched = append(comparison.Unmatched, Unmatched{
+			Side:          side,
+			Kind:          kind,
+			Algorithm:     algorithm,
+			RunName:       runName,
+			File:          file,
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+
+	baseCPU := make(map[string]stats.CPUStats)
+	for _, stat := range baseline.CPU {
+		baseCPU[matchKey(stat.Algorithm, stat.RunName, stat.File)] = stat
+	}
+	candCPU := make(map[string]bool)
+	for _, cand := range candidate.CPU {
+		key := matchKey(cand.Algorithm, cand.RunName, cand.File)
+		candCPU[key] = true
+		base, ok := baseCPU[key]
+		if !ok {
+			unmatched(SideCandidate, "cpu", cand.Algorithm, cand.RunName, cand.File, cand.FileSizeBytes)
+			continue
+		}
+		comparison.Matched++
+		if len(base.Samples) == 0 || len(cand.Samples) == 0 {
+			continue
+		}
+
+		_, p := stats.MannWhitneyU(stats.SampleCycles(base.Samples), stats.SampleCycles(cand.Samples))
+		result := Regression{
+			Kind:          "cpu",
+			Metric:        "Median Cycles",
+			Algorithm:     cand.Algorithm,
+			RunName:       cand.RunName,
+			File:          cand.File,
+			FileSizeBytes: cand.FileSizeBytes,
+			Baseline:      base.Median,
+			Candidate:     cand.Median,
+			Change:        stats.RelativeChange(base.Median, cand.Median),
+			Tested:        true,
+			PValue:        p,
+		}
+		result.Outcome = classifyChange(result.Change, threshold, p < alpha)
+		comparison.Regressions = append(comparison.Regressions, result)
+	}
+	for _, stat := range baseline.CPU {
+		if !candCPU[matchKey(stat.Algorithm, stat.RunName, stat.File)] {
+			unmatched(SideBaseline, "cpu", stat.Algorithm, stat.RunName, stat.File, stat.FileSizeBytes)
+		}
+	}
+
+	baseMemory := make(map[string]stats.MemoryStats)
+	for _, stat := range baseline.Memory {
+		baseMemory[matchKey(stat.Algorithm, stat.RunName, stat.File)] = stat
+	}
+	candMemory := make(map[string]bool)
+	for _, cand := range candidate.Memory {
+		key := matchKey(cand.Algorithm, cand.RunName, cand.File)
+		candMemory[key] = true
+		base, ok := baseMemory[key]
+		if !ok {
+			unmatched(SideCandidate, "memory", cand.Algorithm, cand.RunName, cand.File, cand.FileSizeBytes)
+			continue
+		}
+		comparison.Matched++
+		if !base.Complete() || !cand.Complete() {
+			continue
+		}
+
+		metrics := []struct {
+			name      string
+			base, new float64
+		}{
+			{"Peak Memory (bytes)", float64(base.PeakMemoryUsage), float64(cand.PeakMemoryUsage)},
+			{"Total Allocated (bytes)", float64(base.TotalAllocated), float64(cand.TotalAllocated)},
+		}
+		for _, m := range metrics {
+			result := Regression{
+				Kind:          "memory",
+				Metric:        m.name,
+				Algorithm:     cand.Algorithm,
+				RunName:       cand.RunName,
+				File:          cand.File,
+				FileSizeBytes: cand.FileSizeBytes,
+				Baseline:      m.base,
+				Candidate:     m.new,
+				Change:        stats.RelativeChange(m.base, m.new),
+			}
+			result.Outcome = classifyChange(result.Change, threshold, true)
+			comparison.Regressions = append(comparison.Regressions, result)
+		}
+	}
+	for _, stat := range baseline.Memory {
+		if !candMemory[matchKey(stat.Algorithm, stat.RunName, stat.File)] {
+			unmatched(SideBaseline, "memory", stat.Algorithm, stat.RunName, stat.File, stat.FileSizeBytes)
+		}
+	}
+
+	results := comparison.Regressions
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Kind != results[j].Kind {
+			return results[i].Kind < results[j].Kind
+		}
+		if results[i].Algorithm != results[j].Algorithm {
+			return results[i].Algorithm < results[j].Algorithm
+		}
+		if results[i].RunName != results[j].RunName {
+			return results[i].RunName < results[j].RunName
+		}
+		return results[i].FileSizeBytes < results[j].FileSizeBytes
+	})
+	missing := comparison.Unmatched
+	sort.SliceStable(missing, func(i, j int) bool {
+		if missing[i].Side != missing[j].Side {
+			return missing[i].Side == SideBaseline
+		}
+		if missing[i].Kind != missing[j].Kind {
+			return missing[i].Kind < missing[j].Kind
+		}
+		if missing[i].Algorithm != missing[j].Algorithm {
+			return missing[i].Algorithm < missing[j].Algorithm
+		}
+		if missing[i].RunName != missing[j].RunName {
+			return missing[i].RunName < missing[j].RunName
+		}
+		return missing[i].FileSizeBytes < missing[j].FileSizeBytes
+	})
+	return comparison
+}
+
+func classifyChange(change, threshold float64, significant bool) string {
+	switch {
+	case !significant:
+		return OutcomeUnchanged
+	case change > threshold:
+		return OutcomeRegressed
+	case change < -threshold:
+		return OutcomeImproved
+	default:
+		return OutcomeUnchanged
+	}
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/history"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// SchemaVersion is bumped whenever a field of the JSON report is renamed,
+// removed or changes meaning. Adding fields does not change the version.
+const SchemaVersion = 1
+
+// Report is the JSON form of one aggregation. The schema is
+// documented in the README under "JSON Output".
+type Report struct {
+	SchemaVersion int                 `json:"schema_version"`
+	GeneratedAt   time.Time           `json:"generated_at"`
+	GitCommit     string              `json:"git_commit,omitempty"`
+	Metadata      history.RunMetadata `json:"metadata"`
+	Unit          string              `json:"unit"`
+	CPUStats      []stats.CPUStats    `json:"cpu_stats"`
+	MemoryStats   []stats.MemoryStats `json:"memory_stats"`
+	Diagnostics   Diagnostics         `json:"diagnostics"`
+	Analysis      Findings            `json:"analysis"`
+}
+
+// Diagnostics holds the data quality checks run over the inputs
+type Diagnostics struct {
+	Clock           []stats.ClockDiagnostic `json:"clock"`
+	MemoryIntegrity []stats.MemoryIntegrity `json:"memory_integrity"`
+	ChangePoints    []history.ChangePoint   `json:"change_points"`
+}
+
+// Findings holds the results derived from comparing benchmarks
+type Findings struct {
+	Significance  []stats.PairwiseComparison `json:"significance"`
+	RunComparison []stats.RunComparison      `json:"run_comparison"`
+	BaselineRun   string                     `json:"baseline_run"`
+	Crossovers    []stats.Crossover          `json:"crossovers"`
+	Complexity    []stats.ComplexityFit      `json:"complexity"`
+}
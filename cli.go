@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// outputFormats lists the formats -formats accepts, in the order they are
+// written
+var outputFormats = []string{"xlsx", "json", "markdown", "html", "svg"}
+
+// parseFormats validates a comma separated list of output formats
+func parseFormats(value string) (map[string]bool, error) {
+	formats := make(map[string]bool)
+	for _, format := range splitList(strings.ToLower(value)) {
+		known := false
+		for _, f := range outputFormats {
+			known = known || f == format
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown output format %q (expected %s)", format, strings.Join(outputFormats, ", "))
+		}
+		formats[format] = true
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no output formats given")
+	}
+	return formats, nil
+}
+
+// outputPaths derives a path for every selected format from the workbook
+// path by swapping its extension. SVG charts go to a directory.
+func outputPaths(output string, formats map[string]bool) map[string]string {
+	base := strings.TrimSuffix(output, filepath.Ext(output))
+	extensions := map[string]string{
+		"xlsx":     ".xlsx",
+		"json":     ".json",
+		"markdown": ".md",
+		"html":     ".html",
+		"svg":      "_charts",
+	}
+
+	paths := make(map[string]string)
+	for format := range formats {
+		paths[format] = base + extensions[format]
+	}
+	if formats["xlsx"] {
+		paths["xlsx"] = output
+	}
+	return paths
+}
+
+// filterFlags registers the result filter flags on fs and returns a function
+// that builds the filter once fs has been parsed
+func filterFlags(fs *flag.FlagSet) func() (loader.Filter, error) {
+	algorithms := fs.String("algorithm", "", "comma separated `list` of algorithms to include (default all)")
+	runNames := fs.String("run", "", "comma separated `list` of run names to include (default all)")
+	minSize := fs.String("min-size", "", "smallest input `size` to include, such as 1K")
+	maxSize := fs.String("max-size", "", "largest input `size` to include, such as 50K")
+
+	return func() (loader.Filter, error) {
+		filter := loader.Filter{
+			Algorithms: splitList(*algorithms),
+			RunNames:   splitList(*runNames),
+		}
+		var err error
+		if *minSize != "" {
+			if filter.MinSize, err = loader.ParseSize(*minSize); err != nil {
+				return loader.Filter{}, fmt.Errorf("invalid -min-size %q: %w", *minSize, err)
+			}
+		}
+		if *maxSize != "" {
+			if filter.MaxSize, err = loader.ParseSize(*maxSize); err != nil {
+				return loader.Filter{}, fmt.Errorf("invalid -max-size %q: %w", *maxSize, err)
+			}
+		}
+		if filter.MaxSize > 0 && filter.MinSize > filter.MaxSize {
+			return loader.Filter{}, fmt.Errorf("-min-size %s is larger than -max-size %s", *minSize, *maxSize)
+		}
+		return filter, nil
+	}
+}
+
+// isHelpArg reports whether arg asks for usage, matching the benchmark binary
+func isHelpArg(arg string) bool {
+	return arg == "--help" || arg == "-help" || arg == "-h"
+}
+
+// parseFlags parses args into fs. Asking for help prints usage to stdout, as
+// the benchmark binary does, and returns flag.ErrHelp. Other errors have
+// already been reported on stderr by fs.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if isHelpArg(arg) {
+			fs.SetOutput(os.Stdout)
+			fs.Usage()
+			return flag.ErrHelp
+		}
+	}
+	return fs.Parse(args)
+}
+
+// commands lists the subcommands shown by the top level usage
+var commands = []struct {
+	Name     string
+	Synopsis string
+	Summary  string
+}{
+	{"aggregate", "[aggregate] [options]", "build the workbook and other outputs (default)"},
+	{"validate", "validate [options]", "lint result files and report problems"},
+	{"compare", "compare [options] <baseline> <candidate>", "diff two result sets and flag regressions"},
+	{"export", "export [options]", "convert the aggregation to other formats"},
+	{"serve", "serve [options]", "serve a local dashboard of the results"},
+	{"history", "history <list|query|prune> [options]", "inspect the history archive"},
+}
+
+// printUsage writes the top level usage in the same layout as the benchmark
+// binary's --help
+func printUsage(w io.Writer) {
+	prog := filepath.Base(os.Args[0])
+	fmt.Fprintf(w, "Usage:\n")
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %s %s\n", prog, command.Synopsis)
+	}
+	fmt.Fprintf(w, "\nAvailable commands:\n")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, command := range commands {
+		fmt.Fprintf(tw, "  - %s\t%s\n", command.Name, command.Summary)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nUse '%s <command> --help' to see the options of a command.\n", prog)
+}
+
+// printCommandUsage writes the usage of one command: its synopsis, any extra
+// sections and every flag registered on fs
+func printCommandUsage(w io.Writer, fs *flag.FlagSet, synopsis string, sections ...string) {
+	fmt.Fprintf(w, "Usage:\n  %s %s\n", filepath.Base(os.Args[0]), synopsis)
+	for _, section := range sections {
+		fmt.Fprintf(w, "\n%s\n", strings.TrimRight(section, "\n"))
+	}
+	fmt.Fprintf(w, "\nOptions:\n")
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fs.VisitAll(func(f *flag.Flag) {
+		name, usage := flag.UnquoteUsage(f)
+		if name != "" {
+			name = " <" + name + ">"
+		}
+		if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" {
+			usage += fmt.Sprintf(" (default %s)", f.DefValue)
+		}
+		fmt.Fprintf(tw, "  -%s%s\t%s\n", f.Name, name, usage)
+	})
+	tw.Flush()
+}
+
+// formatsSection lists the output formats for command usage
+func formatsSection() string {
+	var b strings.Builder
+	b.WriteString("Output formats:\n")
+	for _, format := range outputFormats {
+		fmt.Fprintf(&b, "  - %s\n", format)
+	}
+	return b.String()
+}
+
+// outlierFlags registers the outlier policy flags on fs and returns a
+// function that builds the policy once the flags have been parsed
+func outlierFlags(fs *flag.FlagSet) func() (stats.OutlierPolicy, error) {
+	var policy stats.OutlierPolicy
+	var method string
+	fs.IntVar(&policy.WarmupRuns, "warmup", 0, "number of leading CPU runs to drop as warm-up")
+	fs.StringVar(&method, "outliers", string(stats.OutlierNone), "outlier rejection for CPU runs: none, tukey or mad")
+	fs.Float64Var(&policy.Threshold, "outlier-threshold", 0, "fence multiplier (default 1.5 for tukey, 3.5 for mad)")
+
+	return func() (stats.OutlierPolicy, error) {
+		parsed, err := stats.ParseOutlierMethod(method)
+		if err != nil {
+			return stats.OutlierPolicy{}, err
+		}
+		policy.Method = parsed
+		if err := policy.Validate(); err != nil {
+			return stats.OutlierPolicy{}, err
+		}
+		return policy, nil
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// resultSet holds the statistics loaded for one side of a comparison
+type resultSet struct {
+	Label string
+	// RunName is set when the set is one run name under the results root
+	RunName string
+	loader.Results
+}
+
+// runCompare implements the compare command and returns the process exit
+// code: 0 when nothing regressed, 1 on a regression and 2 on usage errors
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	inputRoot := fs.String("input", "results/sort", "results root used to resolve run names")
+	threshold := fs.Float64("threshold", 0.05, "relative change treated as a regression or improvement")
+	alpha := fs.Float64("alpha", 0.05, "significance level for the Mann-Whitney test on CPU samples")
+	outlierPolicy := outlierFlags(fs)
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, "compare [options] <baseline> <candidate>",
+			"Each side is a results directory containing cpu/ and memory/, or a run name\nunder -input. Exits 1 when anything regressed.")
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err)
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	policy, err := outlierPolicy()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	baseline, err := loadResultSet(fs.Arg(0), *inputRoot, policy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+		return 2
+	}
+	candidate, err := loadResultSet(fs.Arg(1), *inputRoot, policy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading candidate: %v\n", err)
+		return 2
+	}
+
+	// Two run names are compared with each other; directories are matched
+	// run name by run name so results from different machines never pair up
+	acrossRuns := baseline.RunName != "" && candidate.RunName != ""
+	comparison := analysis.CompareResults(baseline.Results, candidate.Results, *threshold, *alpha, acrossRuns)
+	printRegressionResults(os.Stdout, baseline, candidate, comparison)
+
+	// Nothing to compare usually means mismatched run names or inputs
+	if comparison.Matched == 0 {
+		fmt.Fprintf(os.Stderr, "No benchmarks in %s match %s\n", candidate.Label, baseline.Label)
+		return 2
+	}
+	for _, r := range comparison.Regressions {
+		if r.Outcome == analysis.OutcomeRegressed {
+			return 1
+		}
+	}
+	return 0
+}
+
+// loadResultSet loads a results directory, or the results of one run name
+// under inputRoot when spec is not a directory
+func loadResultSet(spec, inputRoot string, policy stats.OutlierPolicy) (resultSet, error) {
+	root := spec
+	runName := ""
+	if info, err := os.Stat(spec); err != nil || !info.IsDir() {
+		root = inputRoot
+		runName = spec
+	}
+
+	dir := loader.Dir{Root: root, Policy: policy}
+	if runName != "" {
+		dir.Filter.RunNames = []string{runName}
+	}
+	results, err := dir.Load()
+	if err != nil {
+		return resultSet{}, err
+	}
+
+	set := resultSet{Label: spec, RunName: runName, Results: results}
+
+	if len(set.CPU) == 0 && len(set.Memory) == 0 {
+		return resultSet{}, fmt.Errorf("no results found for %q", spec)
+	}
+	return set, nil
+}
+
+func printRegressionResults(w *os.File, baseline, candidate resultSet, comparison analysis.Comparison) {
+	fmt.Fprintf(w, "Comparing %s (baseline) with %s (candidate)\n\n", baseline.Label, candidate.Label)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OUTCOME\tKIND\tALGORITHM\tRUN\tFILE\tMETRIC\tBASELINE\tCANDIDATE\tCHANGE\tP-VALUE")
+	counts := make(map[string]int)
+	for _, r := range comparison.Regressions {
+		pValue := "-"
+		if r.Tested {
+			pValue = fmt.Sprintf("%.4f", r.PValue)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%.0f\t%.0f\t%+.2f%%\t%s\n",
+			strings.ToUpper(r.Outcome), r.Kind, r.Algorithm, r.RunName, r.File, r.Metric, r.Baseline, r.Candidate, r.Change*100, pValue)
+		counts[r.Outcome]++
+	}
+	tw.Flush()
+
+	// Benchmarks missing from one side are listed so lost coverage is seen
+	if len(comparison.Unmatched) > 0 {
+		fmt.Fprintf(w, "\nNot compared, found on one side only:\n\n")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ONLY IN\tKIND\tALGORITHM\tRUN\tFILE")
+		for _, u := range comparison.Unmatched {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", strings.ToUpper(u.Side), u.Kind, u.Algorithm, u.RunName, u.File)
+			counts[u.Side]++
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintf(w, "\n%d regressed, %d improved, %d unchanged, %d only in baseline, %d only in candidate\n",
+		counts[analysis.OutcomeRegressed], counts[analysis.OutcomeImproved], counts[analysis.OutcomeUnchanged],
+		counts[analysis.SideBaseline], counts[analysis.SideCandidate])
+}
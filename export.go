@@ -0,0 +1,9 @@
+package main
+
+// runExport implements the export command, which writes the aggregation in
+// other formats without recording it in the history archive
+func runExport(args []string) int {
+	return runOutputCommand("export", args, "export [options]",
+		"Writes the aggregation in the selected formats, JSON by default, without\nrecording it in the history archive.",
+		"json", false)
+}
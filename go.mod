@@ -1,4 +1,4 @@
-module data-transport-phenomena
+module github.com/jfkonecn/data-transport-phenomena
 
 go 1.21
 
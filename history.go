@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/history"
+)
+
+// runHistory implements the history command and its list, query and prune
+// subcommands, returning the process exit code
+func runHistory(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n  %s history <list|query|prune> [options]\n\nUse %s history <command> -h for command options.\n", filepath.Base(os.Args[0]), filepath.Base(os.Args[0]))
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		return historyList(args[1:])
+	case "query":
+		return historyQuery(args[1:])
+	case "prune":
+		return historyPrune(args[1:])
+	case "-h", "-help", "--help":
+		usage()
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown history command: %s\n", args[0])
+		usage()
+		return 2
+	}
+}
+
+func historyList(args []string) int {
+	fs := flag.NewFlagSet("history list", flag.ContinueOnError)
+	path := fs.String("history", history.DefaultPath, "history archive")
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, "history list [options]", "Lists the aggregations recorded in the history archive.")
+	}
+	if code, ok := parseHistoryFlags(fs, args); !ok {
+		return code
+	}
+
+	records, err := history.Read(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTIMESTAMP\tCOMMIT\tHOST\tCPU STATS\tMEMORY STATS")
+	for _, record := range records {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\n",
+			record.ID, record.Timestamp.Format(time.RFC3339), history.ShortCommit(record.GitCommit), record.Metadata.Hostname, len(record.CPUStats), len(record.MemoryStats))
+	}
+	tw.Flush()
+	return 0
+}
+
+func historyQuery(args []string) int {
+	fs := flag.NewFlagSet("history query", flag.ContinueOnError)
+	path := fs.String("history", history.DefaultPath, "history archive")
+	id := fs.String("id", "", "only show the record with this ID")
+	algorithm := fs.String("algorithm", "", "only show this algorithm")
+	runName := fs.String("run", "", "only show this run name")
+	file := fs.String("file", "", "only show this input file")
+	since := fs.String("since", "", "only show records at or after this time")
+	until := fs.String("until", "", "only show records before this time")
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, "history query [options]", "Prints the CPU statistics recorded in the history archive.")
+	}
+	if code, ok := parseHistoryFlags(fs, args); !ok {
+		return code
+	}
+
+	records, err := history.Read(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	records, err = history.FilterByTime(records, *since, *until)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCOMMIT\tALGORITHM\tRUN\tFILE\tMEDIAN CYCLES\tAVERAGE CYCLES\tSAMPLES")
+	for _, record := range records {
+		if *id != "" && record.ID != *id {
+			continue
+		}
+		for _, stat := range record.CPUStats {
+			if (*algorithm != "" && stat.Algorithm != *algorithm) ||
+				(*runName != "" && stat.RunName != *runName) ||
+				(*file != "" && stat.File != *file) {
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%.0f\t%.0f\t%d\n",
+				record.ID, history.ShortCommit(record.GitCommit), stat.Algorithm, stat.RunName, stat.File, stat.Median, stat.Average, stat.Count)
+		}
+	}
+	tw.Flush()
+	return 0
+}
+
+func historyPrune(args []string) int {
+	fs := flag.NewFlagSet("history prune", flag.ContinueOnError)
+	path := fs.String("history", history.DefaultPath, "history archive")
+	keep := fs.Int("keep", 0, "keep only the newest n records (0 keeps all)")
+	before := fs.String("before", "", "drop records older than this time")
+	dryRun := fs.Bool("dry-run", false, "report what would be removed without changing the archive")
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, "history prune [options]", "Removes old records from the history archive. Needs -keep or -before.")
+	}
+	if code, ok := parseHistoryFlags(fs, args); !ok {
+		return code
+	}
+	if *keep < 0 || *keep == 0 && *before == "" {
+		fmt.Fprintln(os.Stderr, "history prune needs a positive -keep or -before")
+		return 2
+	}
+
+	records, err := history.Read(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	kept := records
+	if *before != "" {
+		kept, err = history.FilterByTime(kept, *before, "")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	if *keep > 0 && len(kept) > *keep {
+		kept = kept[len(kept)-*keep:]
+	}
+
+	removed := len(records) - len(kept)
+	if *dryRun {
+		fmt.Printf("Would remove %d of %d records from %s\n", removed, len(records), *path)
+		return 0
+	}
+	if removed == 0 {
+		fmt.Printf("Nothing to prune in %s\n", *path)
+		return 0
+	}
+	if err := history.Write(*path, kept); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("Removed %d of %d records from %s\n", removed, len(records), *path)
+	return 0
+}
+
+// parseHistoryFlags parses the flags of a history command with parseFlags.
+// When parsing stops the command, ok is false and code is its exit code.
+func parseHistoryFlags(fs *flag.FlagSet, args []string) (code int, ok bool) {
+	if err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err), false
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Unexpected argument: %s\nUse --help to see available options.\n", fs.Arg(0))
+		return 2, false
+	}
+	return 0, true
+}
@@ -0,0 +1,211 @@
+// Package history stores aggregations in an append-only JSON Lines archive
+// and tracks benchmarks across them.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// DefaultPath is the conventional history archive, read by the history
+// commands unless overridden. Aggregations are only recorded when a path is
+// given.
+const DefaultPath = "results/history.jsonl"
+
+// TimeLayout is used for record IDs and date flags
+const TimeLayout = "20060102T150405.000Z"
+
+// RunMetadata describes the inputs and settings of one aggregation
+type RunMetadata struct {
+	CPUDir        string                `json:"cpu_dir"`
+	MemoryDir     string                `json:"memory_dir"`
+	Hostname      string                `json:"hostname"`
+	OutlierPolicy stats.OutlierPolicy   `json:"outlier_policy"`
+	Bootstrap     stats.BootstrapConfig `json:"bootstrap"`
+}
+
+// Record is one aggregation stored as a line of the history archive
+type Record struct {
+	ID          string              `json:"id"`
+	Timestamp   time.Time           `json:"timestamp"`
+	GitCommit   string              `json:"git_commit,omitempty"`
+	Metadata    RunMetadata         `json:"metadata"`
+	CPUStats    []stats.CPUStats    `json:"cpu_stats"`
+	MemoryStats []stats.MemoryStats `json:"memory_stats"`
+}
+
+// NewRecord stamps an aggregation with the current time and commit
+func NewRecord(metadata RunMetadata, cpuStats []stats.CPUStats, memoryStats []stats.MemoryStats) Record {
+	now := time.Now().UTC()
+	return Record{
+		ID:          now.Format(TimeLayout),
+		Timestamp:   now,
+		GitCommit:   GitCommit(),
+		Metadata:    metadata,
+		CPUStats:    cpuStats,
+		MemoryStats: memoryStats,
+	}
+}
+
+// GitCommit returns the current commit, marked dirty when the working tree
+// has changes, or an empty string outside a git checkout
+func GitCommit() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	commit := strings.TrimSpace(string(out))
+
+	status, err := exec.Command("git", "status", "--porcelain").Output()
+	if err == nil && len(strings.TrimSpace(string(status))) > 0 {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+// Append adds one record to the end of the archive
+func Append(path string, record Record) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating history directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening history %s: %w", path, err)
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error encoding history record: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing history %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// Read loads every record in the archive, oldest first. A missing
+// archive is treated as empty.
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening history %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("error decoding history %s at line %d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history %s: %w", path, err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// Write replaces the archive with records, via a temporary file so an
+// interrupted prune cannot truncate it
+func Write(path string, records []Record) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".history-*.jsonl")
+	if err != nil {
+		return fmt.Errorf("error creating temporary history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("error encoding history record %s: %w", record.ID, err)
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing temporary history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing temporary history file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("error setting history permissions: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ParseTime accepts a date, an RFC 3339 timestamp or a record ID
+func ParseTime(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339, TimeLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (expected YYYY-MM-DD, RFC 3339 or a record ID)", value)
+}
+
+// FilterByTime keeps records in [since, until); empty bounds are open
+func FilterByTime(records []Record, since, until string) ([]Record, error) {
+	var from, to time.Time
+	var err error
+	if since != "" {
+		if from, err = ParseTime(since); err != nil {
+			return nil, err
+		}
+	}
+	if until != "" {
+		if to, err = ParseTime(until); err != nil {
+			return nil, err
+		}
+	}
+
+	var filtered []Record
+	for _, record := range records {
+		if !from.IsZero() && record.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !record.Timestamp.Before(to) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered, nil
+}
+
+// ShortCommit abbreviates a commit hash, keeping the dirty marker
+func ShortCommit(commit string) string {
+	hash, dirty := strings.CutSuffix(commit, "-dirty")
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	if dirty {
+		hash += "-dirty"
+	}
+	return hash
+}
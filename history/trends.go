@@ -0,0 +1,93 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// TrendSeries is the average cycles of one algorithm, run name and input
+// file across historical aggregations, oldest first
+type TrendSeries struct {
+	Algorithm string    `json:"algorithm"`
+	RunName   string    `json:"run_name"`
+	File      string    `json:"file"`
+	RecordIDs []string  `json:"record_ids"`
+	Averages  []float64 `json:"averages"`
+}
+
+// ChangePoint marks the aggregation where a series shifted to a new level
+type ChangePoint struct {
+	Algorithm   string    `json:"algorithm"`
+	RunName     string    `json:"run_name"`
+	File        string    `json:"file"`
+	RecordID    string    `json:"record_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	GitCommit   string    `json:"git_commit"`
+	BeforeMean  float64   `json:"before_mean"`
+	AfterMean   float64   `json:"after_mean"`
+	ShiftChange float64   `json:"shift_change"`
+}
+
+// BuildTrendSeries collects the average cycles of every benchmark across
+// records, which must be ordered oldest first
+func BuildTrendSeries(records []Record) []TrendSeries {
+	series := make(map[string]*TrendSeries)
+	var keys []string
+	for _, record := range records {
+		for _, stat := range record.CPUStats {
+			if stat.Count == 0 {
+				continue
+			}
+			key := stat.Algorithm + "_" + stat.RunName + "_" + stat.File
+			s, ok := series[key]
+			if !ok {
+				s = &TrendSeries{Algorithm: stat.Algorithm, RunName: stat.RunName, File: stat.File}
+				series[key] = s
+				keys = append(keys, key)
+			}
+			s.RecordIDs = append(s.RecordIDs, record.ID)
+			s.Averages = append(s.Averages, stat.Average)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]TrendSeries, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *series[key])
+	}
+	return result
+}
+
+// FindChangePoints runs change-point detection over every series and ties
+// each change back to the aggregation it happened at
+func FindChangePoints(records []Record, series []TrendSeries, minShift float64) []ChangePoint {
+	byID := make(map[string]Record, len(records))
+	for _, record := range records {
+		byID[record.ID] = record
+	}
+
+	var changes []ChangePoint
+	for _, s := range series {
+		indices := stats.DetectChangePoints(s.Averages, minShift)
+		bounds := append(append([]int{0}, indices...), len(s.Averages))
+		for i, index := range indices {
+			before := stats.Mean(s.Averages[bounds[i]:index])
+			after := stats.Mean(s.Averages[index:bounds[i+2]])
+			record := byID[s.RecordIDs[index]]
+			changes = append(changes, ChangePoint{
+				Algorithm:   s.Algorithm,
+				RunName:     s.RunName,
+				File:        s.File,
+				RecordID:    record.ID,
+				Timestamp:   record.Timestamp,
+				GitCommit:   record.GitCommit,
+				BeforeMean:  before,
+				AfterMean:   after,
+				ShiftChange: stats.RelativeChange(before, after),
+			})
+		}
+	}
+	return changes
+}
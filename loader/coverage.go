@@ -0,0 +1,195 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Coverage states of an expected result file
+const (
+	CoverageOK         = "ok"
+	CoverageMissing    = "missing"
+	CoverageEmpty      = "empty"
+	CoverageTruncated  = "truncated"
+	CoverageParseError = "parse-error"
+	CoverageHeaderOnly = "header-only"
+)
+
+// CoverageStates lists the coverage states in report order, ok last
+var CoverageStates = []string{CoverageMissing, CoverageEmpty, CoverageTruncated, CoverageParseError, CoverageHeaderOnly, CoverageOK}
+
+// CoverageEntry is one cell of the algorithm × run name × data file matrix a
+// benchmark campaign is expected to fill
+type CoverageEntry struct {
+	Kind      string `json:"kind"`
+	Algorithm string `json:"algorithm"`
+	RunName   string `json:"run_name"`
+	DataFile  string `json:"data_file"`
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	// Gap is set when the file leaves the campaign incomplete. Header-only
+	// memory traces are not gaps, as algorithms that never allocate log nothing.
+	Gap bool `json:"gap"`
+}
+
+// Coverage is the expected result matrix of one results directory
+type Coverage struct {
+	Algorithms []string
+	RunNames   []string
+	DataFiles  []string
+	Entries    []CoverageEntry
+}
+
+// Gaps returns the entries that leave the campaign incomplete
+func (c Coverage) Gaps() []CoverageEntry {
+	var gaps []CoverageEntry
+	for _, entry := range c.Entries {
+		if entry.Gap {
+			gaps = append(gaps, entry)
+		}
+	}
+	return gaps
+}
+
+// CheckCoverage expects a CPU and a memory result for every input file in
+// dataDir, from every algorithm and run name found under root. A CPU file is
+// truncated when its last record is cut short or it has fewer runs than the
+// most complete CPU file of its run name. Header-only memory traces are not
+// gaps, as algorithms that never allocate log nothing.
+func CheckCoverage(root, dataDir string) (Coverage, error) {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return Coverage{}, fmt.Errorf("error reading data directory: %w", err)
+	}
+	var coverage Coverage
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			coverage.DataFiles = append(coverage.DataFiles, entry.Name())
+		}
+	}
+	if len(coverage.DataFiles) == 0 {
+		return Coverage{}, fmt.Errorf("no input files in %s", dataDir)
+	}
+
+	// Discover the algorithms and run names from either results directory
+	algorithms := make(map[string]bool)
+	runNames := make(map[string]bool)
+	for _, kind := range []string{"cpu", "memory"} {
+		files, err := filepath.Glob(filepath.Join(root, kind, "*.csv"))
+		if err != nil {
+			return Coverage{}, fmt.Errorf("error globbing %s files: %w", kind, err)
+		}
+		for _, file := range files {
+			parts := strings.Split(strings.TrimSuffix(filepath.Base(file), ".csv"), "_")
+			if len(parts) < 3 {
+				continue
+			}
+			algorithms[parts[0]] = true
+			runNames[parts[1]] = true
+		}
+	}
+	coverage.Algorithms = stats.SortedKeys(algorithms)
+	coverage.RunNames = stats.SortedKeys(runNames)
+
+	// Samples per CPU file, to find files with fewer runs than their run name
+	samples := make(map[int]int)
+	mostSamples := make(map[string]int)
+	for _, kind := range []string{"cpu", "memory"} {
+		for _, algorithm := range coverage.Algorithms {
+			for _, runName := range coverage.RunNames {
+				for _, dataFile := range coverage.DataFiles {
+					path := filepath.Join(root, kind, fmt.Sprintf("%s_%s_%s.csv", algorithm, runName, dataFile))
+					status, detail, n := inspectResultFile(path, kind, algorithm, dataFile)
+					if kind == "cpu" && status == CoverageOK {
+						samples[len(coverage.Entries)] = n
+						mostSamples[runName] = max(mostSamples[runName], n)
+					}
+					coverage.Entries = append(coverage.Entries, CoverageEntry{
+						Kind:      kind,
+						Algorithm: algorithm,
+						RunName:   runName,
+						DataFile:  dataFile,
+						Path:      path,
+						Status:    status,
+						Detail:    detail,
+					})
+				}
+			}
+		}
+	}
+
+	for i := range coverage.Entries {
+		entry := &coverage.Entries[i]
+		if n, ok := samples[i]; ok && n < mostSamples[entry.RunName] {
+			entry.Status = CoverageTruncated
+			entry.Detail = fmt.Sprintf("%d of %d runs", n, mostSamples[entry.RunName])
+		}
+		entry.Gap = entry.Status != CoverageOK && !(entry.Status == CoverageHeaderOnly && entry.Kind == "memory")
+	}
+	return coverage, nil
+}
+
+// inspectResultFile returns the coverage status of one result file and the
+// number of records after its header. Records are checked for truncation
+// the way the aggregation reads them.
+func inspectResultFile(path, kind, algorithm, dataFile string) (status, detail string, samples int) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return CoverageMissing, "", 0
+	} else if err != nil {
+		return CoverageMissing, err.Error(), 0
+	}
+	if kind == "memory" {
+		return inspectMemoryTrace(path, algorithm, dataFile)
+	}
+
+	records, empty, err := readRecords(path)
+	if err != nil {
+		return CoverageParseError, err.Error(), 0
+	}
+	if empty {
+		return CoverageEmpty, "", 0
+	}
+	columns, err := MapColumns(records[0], CPUColumns)
+	if err != nil {
+		return CoverageParseError, err.Error(), 0
+	}
+	for i := 1; i < len(records); i++ {
+		if reason := recordTruncation(records, i, columns, algorithm, dataFile); reason != "" {
+			return CoverageTruncated, fmt.Sprintf("line %d: %s", i+1, reason), 0
+		}
+	}
+	if len(records) == 1 {
+		return CoverageHeaderOnly, "", 0
+	}
+	return CoverageOK, "", len(records) - 1
+}
+
+// inspectMemoryTrace maps the status the aggregation gives a memory trace to
+// its coverage status
+func inspectMemoryTrace(path, algorithm, dataFile string) (status, detail string, samples int) {
+	fileSizeBytes, _ := InputFileSize(dataFile)
+	trace := classifyMemoryTrace(path, algorithm, dataFile, fileSizeBytes)
+	if trace.Err != nil {
+		detail = trace.Err.Error()
+	} else if len(trace.Problems) > 0 {
+		detail = fmt.Sprintf("line %d: %s", trace.Problems[0].Line, trace.Problems[0].Reason)
+	}
+
+	switch trace.Status {
+	case stats.MemoryStatusEmptyFile:
+		return CoverageEmpty, detail, 0
+	case stats.MemoryStatusTruncated:
+		return CoverageTruncated, detail, trace.Records
+	case stats.MemoryStatusParseError:
+		return CoverageParseError, detail, trace.Records
+	}
+	if trace.Records == 0 {
+		return CoverageHeaderOnly, "", 0
+	}
+	return CoverageOK, "", trace.Records
+}
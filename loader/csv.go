@@ -0,0 +1,97 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ParseSize parses a size such as "100", "50K" or "1M", where K means
+// thousands and M means millions
+func ParseSize(value string) (int, error) {
+	// Handle suffixes like K (thousands), M (millions)
+	var multiplier int = 1
+	if strings.HasSuffix(value, "K") {
+		multiplier = 1000
+		value = strings.TrimSuffix(value, "K")
+	} else if strings.HasSuffix(value, "M") {
+		multiplier = 1000000
+		value = strings.TrimSuffix(value, "M")
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return number * multiplier, nil
+}
+
+// InputFileSize returns the size in an input file name such as "06_100K.bin"
+func InputFileSize(fileInfo string) (int, error) {
+	fileSizeParts := strings.Split(fileInfo, "_")
+	if len(fileSizeParts) < 2 {
+		return 0, fmt.Errorf("invalid file info format: %s", fileInfo)
+	}
+
+	fileSizeStr := fileSizeParts[len(fileSizeParts)-1] // Get the last part
+	// Remove .bin extension
+	fileSizeStr = strings.TrimSuffix(fileSizeStr, ".bin")
+
+	return ParseSize(fileSizeStr)
+}
+
+// CPUColumns lists the columns the aggregator reads from CPU result files
+var CPUColumns = []string{"run_number", "cycles", "cpu_clock_hz", "file_size_bytes"}
+
+// MemoryColumns lists the columns the aggregator reads from memory result files
+var MemoryColumns = []string{"alignment", "allocation_type", "allocation_size_bytes"}
+
+// ColumnMap maps a CSV column name to its index within a record
+type ColumnMap map[string]int
+
+// MapColumns resolves column names from a header row and checks that every
+// required column is present. Extra and reordered columns are allowed.
+func MapColumns(header []string, required []string) (ColumnMap, error) {
+	columns := make(ColumnMap, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, exists := columns[name]; exists {
+			return nil, fmt.Errorf("duplicate column %q in header", name)
+		}
+		columns[name] = i
+	}
+
+	var missing []string
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("header is missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// ReadCSV reads every record of a result file. Records may have fewer
+// fields than the header, so a row cut short can be skipped on its own.
+func ReadCSV(filename string) ([][]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV from %s: %w", filename, err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,65 @@
+package loader
+
+import (
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Filter selects the results an aggregation covers. Empty lists and
+// zero sizes match everything.
+type Filter struct {
+	Algorithms []string
+	RunNames   []string
+	MinSize    int
+	MaxSize    int
+}
+
+// Matches reports whether a result passes the filter. Sizes are compared with
+// the size in the input file name, such as 10K in 04_10K.bin, so CPU and
+// memory results are filtered alike.
+func (f Filter) Matches(algorithm, runName, file string) bool {
+	if len(f.Algorithms) > 0 && !containsString(f.Algorithms, algorithm) {
+		return false
+	}
+	if len(f.RunNames) > 0 && !containsString(f.RunNames, runName) {
+		return false
+	}
+	if f.MinSize > 0 || f.MaxSize > 0 {
+		size, err := InputFileSize(file)
+		if err != nil {
+			return false
+		}
+		if size < f.MinSize || f.MaxSize > 0 && size > f.MaxSize {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Filter) filterCPUStats(all []stats.CPUStats) []stats.CPUStats {
+	var kept []stats.CPUStats
+	for _, stat := range all {
+		if f.Matches(stat.Algorithm, stat.RunName, stat.File) {
+			kept = append(kept, stat)
+		}
+	}
+	return kept
+}
+
+func (f Filter) filterMemoryStats(all []stats.MemoryStats) []stats.MemoryStats {
+	var kept []stats.MemoryStats
+	for _, stat := range all {
+		if f.Matches(stat.Algorithm, stat.RunName, stat.File) {
+			kept = append(kept, stat)
+		}
+	}
+	return kept
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+// Package loader reads the CSV files written by the benchmark binary and turns
+// them into per-benchmark statistics.
+package loader
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Loader produces the statistics of one set of benchmark results. Dir loads
+// them from the CSV files written by the benchmark binary; services can
+// supply their own source.
+type Loader interface {
+	Load() (Results, error)
+}
+
+// Dir loads the result files under one results directory containing cpu/
+// and memory/. Confidence intervals are skipped when Bootstrap.Resamples is
+// 0.
+type Dir struct {
+	Root      string
+	Filter    Filter
+	Policy    stats.OutlierPolicy
+	Bootstrap stats.BootstrapConfig
+}
+
+// Results holds the statistics loaded from one results directory, sorted by
+// algorithm, run name and input size, with the settings they were
+// calculated with
+type Results struct {
+	CPUDir    string
+	MemoryDir string
+	CPU       []stats.CPUStats
+	Memory    []stats.MemoryStats
+	Policy    stats.OutlierPolicy
+	Bootstrap stats.BootstrapConfig
+}
+
+// Load is the loading pipeline shared by every command: it processes the
+// cpu/ and memory/ directories under the root, applies the filter and adds
+// bootstrap confidence intervals
+func (d Dir) Load() (Results, error) {
+	results := Results{
+		CPUDir:    filepath.Join(d.Root, "cpu"),
+		MemoryDir: filepath.Join(d.Root, "memory"),
+		Policy:    d.Policy,
+		Bootstrap: d.Bootstrap,
+	}
+
+	cpuStats, err := ProcessCPUData(results.CPUDir, d.Policy)
+	if err != nil {
+		return Results{}, err
+	}
+	results.CPU = d.Filter.filterCPUStats(cpuStats)
+	if d.Bootstrap.Resamples > 0 {
+		stats.AddConfidenceIntervals(results.CPU, d.Bootstrap)
+	}
+	stats.SortCPUStats(results.CPU)
+
+	memoryStats, err := ProcessMemoryData(results.MemoryDir)
+	if err != nil {
+		return Results{}, err
+	}
+	results.Memory = d.Filter.filterMemoryStats(memoryStats)
+	stats.SortMemoryStats(results.Memory)
+
+	return results, nil
+}
+
+// ProcessCPUData calculates statistics for every CPU result file in cpuDir,
+// applying the outlier policy to each file's samples
+func ProcessCPUData(cpuDir string, policy stats.OutlierPolicy) ([]stats.CPUStats, error) {
+	var allStats []stats.CPUStats
+	algorithmFileMap := make(map[string][]stats.CPUData)
+
+	// Read all CPU CSV files
+	files, err := filepath.Glob(filepath.Join(cpuDir, "*.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("error globbing CPU files: %w", err)
+	}
+
+	for _, file := range files {
+		records, err := ReadCSV(file)
+		if err != nil {
+			log.Printf("Error reading %s: %v", file, err)
+			continue
+		}
+
+		// Extract algorithm and run name from filename
+		baseName := filepath.Base(file)
+		// Remove .csv extension
+		baseName = strings.TrimSuffix(baseName, ".csv")
+		// Split by underscore to get algorithm and run info
+		parts := strings.Split(baseName, "_")
+		if len(parts) < 3 {
+			log.Printf("Warning: invalid filename format: %s", file)
+			continue
+		}
+
+		algorithm := parts[0]
+		runName := parts[1]                      // e.g., "i9"
+		fileInfo := strings.Join(parts[2:], "_") // e.g., "01_100.bin"
+
+		if len(records) == 0 {
+			log.Printf("Warning: skipping empty file %s", file)
+			continue
+		}
+
+		// Resolve column positions from the header
+		columns, err := MapColumns(records[0], CPUColumns)
+		if err != nil {
+			log.Printf("Error reading %s: %v", file, err)
+			continue
+		}
+
+		// Skip header
+		for i := 1; i < len(records); i++ {
+			record := records[i]
+			if reason := recordTruncation(records, i, columns, algorithm, fileInfo); reason != "" {
+				log.Printf("Warning: skipping %s in %s at line %d", reason, file, i+1)
+				continue
+			}
+
+			runNumber, err := strconv.Atoi(record[columns["run_number"]])
+			if err != nil {
+				log.Printf("Warning: invalid run number in %s at line %d: %v", file, i+1, err)
+				continue
+			}
+
+			cycles, err := strconv.ParseInt(record[columns["cycles"]], 10, 64)
+			if err != nil {
+				log.Printf("Warning: invalid cycles in %s at line %d: %v", file, i+1, err)
+				continue
+			}
+
+			cpuClockHz, err := strconv.ParseInt(record[columns["cpu_clock_hz"]], 10, 64)
+			if err != nil {
+				log.Printf("Warning: invalid CPU clock Hz in %s at line %d: %v", file, i+1, err)
+				continue
+			}
+
+			fileSizeBytes, err := strconv.Atoi(record[columns["file_size_bytes"]])
+			if err != nil {
+				log.Printf("Warning: invalid file size bytes in %s at line %d: %v", file, i+1, err)
+				continue
+			}
+
+			key := fmt.Sprintf("%s_%s_%s", algorithm, runName, fileInfo)
+			algorithmFileMap[key] = append(algorithmFileMap[key], stats.CPUData{
+				RunNumber:     runNumber,
+				Cycles:        cycles,
+				CPUClockHz:    cpuClockHz,
+				Algorithm:     algorithm,
+				File:          fileInfo,
+				FileSizeBytes: fileSizeBytes,
+			})
+		}
+	}
+
+	// Calculate statistics for each algorithm-file combination
+	for key, data := range algorithmFileMap {
+		if len(data) == 0 {
+			continue
+		}
+
+		parts := strings.Split(key, "_")
+		if len(parts) < 3 {
+			log.Printf("Warning: invalid key format: %s", key)
+			continue
+		}
+
+		algorithm := parts[0]
+		runName := parts[1]
+		file := strings.Join(parts[2:], "_")
+
+		kept, rejected := policy.Apply(data)
+		if len(kept) == 0 {
+			log.Printf("Warning: outlier policy rejected every sample for %s", key)
+		}
+
+		stat := stats.CalculateCPUStats(kept, algorithm, runName, file)
+		stat.FileSizeBytes = data[0].FileSizeBytes
+		stat.RawCount = len(data)
+		stat.Rejected = rejected
+		stat.Samples = kept
+		allStats = append(allStats, stat)
+	}
+
+	return allStats, nil
+}
+
+// ProcessMemoryData calculates statistics for every memory trace in
+// memoryDir. Every trace with a valid file name gets a row, with a status
+// telling allocation-free runs apart from empty, truncated and unparseable
+// traces.
+func ProcessMemoryData(memoryDir string) ([]stats.MemoryStats, error) {
+	var allStats []stats.MemoryStats
+
+	// Read all memory CSV files
+	files, err := filepath.Glob(filepath.Join(memoryDir, "*.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("error globbing memory files: %w", err)
+	}
+
+	for _, file := range files {
+		// Extract algorithm and file info from filename
+		baseName := filepath.Base(file)
+		// Remove .csv extension
+		baseName = strings.TrimSuffix(baseName, ".csv")
+		// Split by underscore to get algorithm and file info
+		parts := strings.Split(baseName, "_")
+		if len(parts) < 3 {
+			log.Printf("Warning: invalid filename format: %s", file)
+			continue
+		}
+
+		algorithm := parts[0]
+		runName := parts[1]                      // e.g., "i9"
+		fileInfo := strings.Join(parts[2:], "_") // e.g., "01_100.bin"
+
+		// Extract file size from the file info (e.g., "01_100.bin" -> 100, "06_100K.bin" -> 100000)
+		fileSizeBytes, err := InputFileSize(fileInfo)
+		if err != nil {
+			log.Printf("Warning: invalid file size in filename %s: %v", file, err)
+			continue
+		}
+
+		trace := classifyMemoryTrace(file, algorithm, fileInfo, fileSizeBytes)
+		trace.logProblems(file)
+		stat := stats.CalculateMemoryStats(trace.Data, algorithm, runName, fileInfo)
+		stat.FileSizeBytes = fileSizeBytes
+		if trace.Status != "" {
+			stat.Status = trace.Status
+		}
+		allStats = append(allStats, stat)
+	}
+
+	return allStats, nil
+}
@@ -0,0 +1,180 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// knownAllocationTypes are the events written by the logging allocator
+var knownAllocationTypes = map[string]bool{"ALLOC": true, "FREE": true, "RESIZE": true, "REMAP": true}
+
+// recordProblem is a record the aggregation skips, with the reason why and
+// the status it gives the file
+type recordProblem struct {
+	Line   int
+	Status string
+	Reason string
+}
+
+// readRecords reads every record of a result file, allowing short records.
+// empty is set when the file holds nothing but whitespace.
+func readRecords(file string) (records [][]string, empty bool, err error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, true, nil
+	}
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	records, err = reader.ReadAll()
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading CSV from %s: %w", file, err)
+	}
+	return records, false, nil
+}
+
+// recordTruncation returns why record i of a result file was cut short by
+// the writer, or an empty string when it is whole. Any record can be short
+// of fields; the last one can also be cut off inside a field.
+func recordTruncation(records [][]string, i int, columns ColumnMap, algorithm, fileInfo string) string {
+	if len(records[i]) < len(records[0]) {
+		return "record with missing fields"
+	}
+	if i == len(records)-1 && finalRecordCut(records[i], records[1], columns, algorithm, fileInfo) {
+		return "record cut off inside a field"
+	}
+	return ""
+}
+
+// finalRecordCut reports whether the last record of a result file was cut
+// off inside a field. The benchmark writes the same algorithm, file and file
+// size on every record, so a cut value no longer matches the file name or
+// the first record.
+func finalRecordCut(record, first []string, columns ColumnMap, algorithm, fileInfo string) bool {
+	if index, ok := columns["algorithm"]; ok && record[index] != algorithm {
+		return true
+	}
+	if index, ok := columns["file"]; ok && record[index] != fileInfo {
+		return true
+	}
+	if index, ok := columns["file_size_bytes"]; ok && index < len(first) && record[index] != first[index] {
+		return true
+	}
+	return false
+}
+
+// memoryTrace is a memory trace file classified the way the aggregation
+// reads it. Loading, validation and coverage all use it, so they agree on
+// the status of every trace.
+type memoryTrace struct {
+	Data []stats.MemoryData
+	// Status is empty when every record was used, otherwise it is the
+	// stats.MemoryStatus* value saying why events are missing
+	Status string
+	// Records counts the records after the header
+	Records int
+	// Err is set when the file could not be read at all
+	Err      error
+	Problems []recordProblem
+}
+
+// classifyMemoryTrace parses the events of one memory trace. Records cut
+// short by the writer make the trace truncated; unknown allocation types
+// and unparsable sizes make it a parse error.
+func classifyMemoryTrace(file, algorithm, fileInfo string, fileSizeBytes int) memoryTrace {
+	var trace memoryTrace
+	records, empty, err := readRecords(file)
+	if err != nil {
+		trace.Status = stats.MemoryStatusParseError
+		trace.Err = err
+		return trace
+	}
+	// A run that crashed before logging its header leaves an empty file
+	if empty {
+		trace.Status = stats.MemoryStatusEmptyFile
+		return trace
+	}
+	columns, err := MapColumns(records[0], MemoryColumns)
+	if err != nil {
+		trace.Status = stats.MemoryStatusParseError
+		trace.Err = err
+		return trace
+	}
+
+	trace.Records = len(records) - 1
+	for i := 1; i < len(records); i++ {
+		record := records[i]
+		if reason := recordTruncation(records, i, columns, algorithm, fileInfo); reason != "" {
+			trace.Problems = append(trace.Problems, recordProblem{Line: i + 1, Status: stats.MemoryStatusTruncated, Reason: reason})
+			if trace.Status == "" {
+				trace.Status = stats.MemoryStatusTruncated
+			}
+			continue
+		}
+
+		allocationType := record[columns["allocation_type"]]
+		if !knownAllocationTypes[allocationType] {
+			trace.Problems = append(trace.Problems, recordProblem{Line: i + 1, Status: stats.MemoryStatusParseError, Reason: fmt.Sprintf("unknown allocation type %q", allocationType)})
+			trace.Status = stats.MemoryStatusParseError
+			continue
+		}
+
+		allocationSizeBytes, err := strconv.ParseInt(record[columns["allocation_size_bytes"]], 10, 64)
+		if err != nil {
+			trace.Problems = append(trace.Problems, recordProblem{Line: i + 1, Status: stats.MemoryStatusParseError, Reason: fmt.Sprintf("invalid allocation_size_bytes %q", record[columns["allocation_size_bytes"]])})
+			trace.Status = stats.MemoryStatusParseError
+			continue
+		}
+
+		trace.Data = append(trace.Data, stats.MemoryData{
+			Alignment:           record[columns["alignment"]],
+			AllocationType:      allocationType,
+			AllocationSizeBytes: allocationSizeBytes,
+			Algorithm:           algorithm,
+			File:                fileInfo,
+			FileSizeBytes:       fileSizeBytes,
+		})
+	}
+	return trace
+}
+
+// logProblems reports why events of the trace in file are missing
+func (t memoryTrace) logProblems(file string) {
+	switch {
+	case t.Err != nil:
+		log.Printf("Error reading %s: %v", file, t.Err)
+	case t.Status == stats.MemoryStatusEmptyFile:
+		log.Printf("Warning: empty memory trace %s", file)
+	}
+	for _, problem := range t.Problems {
+		log.Printf("Warning: %s in %s at line %d", problem.Reason, file, problem.Line)
+	}
+}
+
+// summariseProblems folds record problems into one message per status and
+// reason, in order of first appearance
+func summariseProblems(problems []recordProblem) []string {
+	counts := make(map[recordProblem]int)
+	var firsts []recordProblem
+	for _, problem := range problems {
+		key := recordProblem{Status: problem.Status, Reason: problem.Reason}
+		if counts[key] == 0 {
+			firsts = append(firsts, problem)
+		}
+		counts[key]++
+	}
+	messages := make([]string, len(firsts))
+	for i, first := range firsts {
+		count := counts[recordProblem{Status: first.Status, Reason: first.Reason}]
+		messages[i] = fmt.Sprintf("%s: %s (%d records, first at line %d)", first.Status, first.Reason, count, first.Line)
+	}
+	return messages
+}
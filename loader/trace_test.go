@@ -0,0 +1,165 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+const memoryHeader = "alignment,allocation_type,allocation_size_bytes,algorithm,file,file_size_bytes"
+
+// memoryTraceCases are named after the algorithm each trace is written for
+var memoryTraceCases = []struct {
+	algorithm string
+	content   string
+	status    string
+	coverage  string
+}{
+	{
+		algorithm: "ok",
+		content: memoryHeader + "\n" +
+			"mem.Alignment.1,ALLOC,10,ok,01_100.bin,100\n" +
+			"mem.Alignment.1,FREE,10,ok,01_100.bin,100",
+		status:   stats.MemoryStatusOK,
+		coverage: CoverageOK,
+	},
+	{
+		algorithm: "header",
+		content:   memoryHeader,
+		status:    stats.MemoryStatusNoAllocations,
+		coverage:  CoverageHeaderOnly,
+	},
+	{
+		algorithm: "empty",
+		content:   "",
+		status:    stats.MemoryStatusEmptyFile,
+		coverage:  CoverageEmpty,
+	},
+	{
+		algorithm: "short",
+		content: memoryHeader + "\n" +
+			"mem.Alignment.1,ALLOC,10,short,01_100.bin,100\n" +
+			"mem.Alignment.1,FREE,10",
+		status:   stats.MemoryStatusTruncated,
+		coverage: CoverageTruncated,
+	},
+	{
+		algorithm: "cut",
+		content: memoryHeader + "\n" +
+			"mem.Alignment.1,ALLOC,10,cut,01_100.bin,100\n" +
+			"mem.Alignment.1,FREE,10,cut,01_100.bin,10",
+		status:   stats.MemoryStatusTruncated,
+		coverage: CoverageTruncated,
+	},
+	{
+		algorithm: "size",
+		content: memoryHeader + "\n" +
+			"mem.Alignment.1,ALLOC,abc,size,01_100.bin,100\n" +
+			"mem.Alignment.1,FREE,10,size,01_100.bin,100",
+		status:   stats.MemoryStatusParseError,
+		coverage: CoverageParseError,
+	},
+	{
+		algorithm: "type",
+		content: memoryHeader + "\n" +
+			"mem.Alignment.1,MALLOC,10,type,01_100.bin,100\n" +
+			"mem.Alignment.1,FREE,10,type,01_100.bin,100",
+		status:   stats.MemoryStatusParseError,
+		coverage: CoverageParseError,
+	},
+}
+
+// writeMemoryTraceCases writes a results directory with a valid CPU file and
+// one memory trace per case, and a data directory with the matching input
+func writeMemoryTraceCases(t *testing.T) (root, dataDir string) {
+	t.Helper()
+	root = t.TempDir()
+	dataDir = t.TempDir()
+	for _, dir := range []string{filepath.Join(root, "cpu"), filepath.Join(root, "memory")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(dataDir, "01_100.bin"), "")
+	for _, c := range memoryTraceCases {
+		name := c.algorithm + "_i9_01_100.bin.csv"
+		write(filepath.Join(root, "cpu", name), "run_number,cycles,cpu_clock_hz,algorithm,file,file_size_bytes\n"+
+			"1,1000,3000000000,"+c.algorithm+",01_100.bin,100\n"+
+			"2,1100,3000000000,"+c.algorithm+",01_100.bin,100")
+		write(filepath.Join(root, "memory", name), c.content)
+	}
+	return root, dataDir
+}
+
+func TestMemoryTraceStatus(t *testing.T) {
+	root, dataDir := writeMemoryTraceCases(t)
+
+	memoryStats, err := ProcessMemoryData(filepath.Join(root, "memory"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]string)
+	for _, stat := range memoryStats {
+		statuses[stat.Algorithm] = stat.Status
+	}
+
+	lintErrors := make(map[string]int)
+	for _, issue := range Validate(root, 0.02) {
+		if issue.Severity == SeverityError {
+			lintErrors[filepath.Base(issue.File)]++
+		}
+	}
+
+	coverage, err := CheckCoverage(root, dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coverageStatuses := make(map[string]string)
+	for _, entry := range coverage.Entries {
+		if entry.Kind == "memory" {
+			coverageStatuses[entry.Algorithm] = entry.Status
+		}
+	}
+
+	for _, c := range memoryTraceCases {
+		t.Run(c.algorithm, func(t *testing.T) {
+			if got := statuses[c.algorithm]; got != c.status {
+				t.Errorf("status = %q, want %q", got, c.status)
+			}
+			complete := stats.MemoryStats{Status: c.status}.Complete()
+			if got := lintErrors[c.algorithm+"_i9_01_100.bin.csv"]; (got == 0) != complete {
+				t.Errorf("validate found %d errors for a %s trace", got, c.status)
+			}
+			if got := coverageStatuses[c.algorithm]; got != c.coverage {
+				t.Errorf("coverage status = %q, want %q", got, c.coverage)
+			}
+		})
+	}
+}
+
+func TestCoverageCPUFileCutInsideField(t *testing.T) {
+	root, dataDir := writeMemoryTraceCases(t)
+	cut := "run_number,cycles,cpu_clock_hz,algorithm,file,file_size_bytes\n" +
+		"1,1000,3000000000,ok,01_100.bin,100\n" +
+		"2,1100,3000000000,ok,01_100.bin,10"
+	if err := os.WriteFile(filepath.Join(root, "cpu", "ok_i9_01_100.bin.csv"), []byte(cut), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	coverage, err := CheckCoverage(root, dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range coverage.Entries {
+		if entry.Kind == "cpu" && entry.Algorithm == "ok" && entry.Status != CoverageTruncated {
+			t.Errorf("coverage status = %q, want %q", entry.Status, CoverageTruncated)
+		}
+	}
+}
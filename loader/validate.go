@@ -0,0 +1,172 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Severities of validation issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a problem found in a result file or directory
+type Issue struct {
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Message  string `json:"message"`
+}
+
+// Validate lints the files under inputRoot and adds the diagnostics
+// of the loaded results as warnings
+func Validate(inputRoot string, clockDrift float64) []Issue {
+	cpuDir := filepath.Join(inputRoot, "cpu")
+	memoryDir := filepath.Join(inputRoot, "memory")
+
+	issues := lintResultDir(cpuDir, lintCPUFile)
+	issues = append(issues, lintResultDir(memoryDir, lintMemoryTrace)...)
+
+	results, err := Dir{Root: inputRoot}.Load()
+	if err != nil {
+		return append(issues, Issue{Severity: SeverityError, File: inputRoot, Message: err.Error()})
+	}
+	for _, diagnostic := range stats.CheckClockEstimates(results.CPU, clockDrift) {
+		for _, issue := range diagnostic.Issues {
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				File:     filepath.Join(cpuDir, "*_"+diagnostic.RunName+"_*.csv"),
+				Message:  "clock estimates: " + issue,
+			})
+		}
+	}
+	for _, integrity := range stats.CheckMemoryIntegrity(results.Memory) {
+		for _, issue := range integrity.Issues {
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				File:     filepath.Join(memoryDir, fmt.Sprintf("%s_%s_%s.csv", integrity.Algorithm, integrity.RunName, integrity.File)),
+				Message:  "memory trace: " + issue,
+			})
+		}
+	}
+	return issues
+}
+
+// lintFunc checks one result file whose name has been parsed
+type lintFunc func(file, algorithm, fileInfo string, fileSizeBytes int) []Issue
+
+// cpuNumericColumns must hold an integer on every CPU record
+var cpuNumericColumns = []string{"run_number", "cycles", "cpu_clock_hz", "file_size_bytes"}
+
+// lintResultDir checks every CSV file in dir
+func lintResultDir(dir string, lint lintFunc) []Issue {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return []Issue{{Severity: SeverityError, File: dir, Message: "results directory not found"}}
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return []Issue{{Severity: SeverityError, File: dir, Message: err.Error()}}
+	}
+	if len(files) == 0 {
+		return []Issue{{Severity: SeverityError, File: dir, Message: "no result files"}}
+	}
+
+	var issues []Issue
+	for _, file := range files {
+		parts := strings.Split(strings.TrimSuffix(filepath.Base(file), ".csv"), "_")
+		if len(parts) < 3 {
+			issues = append(issues, fileError(file, "file name is not <algorithm>_<run>_<input>.csv"))
+			continue
+		}
+		fileInfo := strings.Join(parts[2:], "_")
+		fileSizeBytes, err := InputFileSize(fileInfo)
+		if err != nil {
+			issues = append(issues, fileError(file, fmt.Sprintf("invalid input size in file name: %v", err)))
+			continue
+		}
+		issues = append(issues, lint(file, parts[0], fileInfo, fileSizeBytes)...)
+	}
+	return issues
+}
+
+// fileError is an error issue for one file
+func fileError(file, message string) Issue {
+	return Issue{Severity: SeverityError, File: file, Message: message}
+}
+
+// lintCPUFile reports the problems that stop a CPU result file from being
+// aggregated in full as errors
+func lintCPUFile(file, algorithm, fileInfo string, _ int) []Issue {
+	records, empty, err := readRecords(file)
+	if err != nil {
+		return []Issue{fileError(file, err.Error())}
+	}
+	if empty {
+		return []Issue{fileError(file, "empty file")}
+	}
+	columns, err := MapColumns(records[0], CPUColumns)
+	if err != nil {
+		return []Issue{fileError(file, err.Error())}
+	}
+	if len(records) == 1 {
+		return []Issue{fileError(file, "no samples after the header")}
+	}
+
+	// Records cut short by the writer are skipped by the aggregator
+	var problems []recordProblem
+	whole := make([]bool, len(records))
+	for i := 1; i < len(records); i++ {
+		if reason := recordTruncation(records, i, columns, algorithm, fileInfo); reason != "" {
+			problems = append(problems, recordProblem{Line: i + 1, Status: stats.MemoryStatusTruncated, Reason: reason})
+			continue
+		}
+		whole[i] = true
+	}
+	var issues []Issue
+	for _, message := range summariseProblems(problems) {
+		issues = append(issues, fileError(file, message))
+	}
+
+	for _, name := range cpuNumericColumns {
+		var invalid, firstLine int
+		for i := 1; i < len(records); i++ {
+			if !whole[i] {
+				continue
+			}
+			if _, err := strconv.ParseInt(records[i][columns[name]], 10, 64); err != nil {
+				if invalid == 0 {
+					firstLine = i + 1
+				}
+				invalid++
+			}
+		}
+		if invalid > 0 {
+			issues = append(issues, fileError(file, fmt.Sprintf("%d invalid %s values (first at line %d)", invalid, name, firstLine)))
+		}
+	}
+	return issues
+}
+
+// lintMemoryTrace reports why the aggregation would not give a memory trace
+// the ok or no-allocations status, as errors. Header-only traces are valid,
+// because algorithms that never allocate log nothing.
+func lintMemoryTrace(file, algorithm, fileInfo string, fileSizeBytes int) []Issue {
+	trace := classifyMemoryTrace(file, algorithm, fileInfo, fileSizeBytes)
+	switch {
+	case trace.Err != nil:
+		return []Issue{fileError(file, trace.Err.Error())}
+	case trace.Status == stats.MemoryStatusEmptyFile:
+		return []Issue{fileError(file, "empty file")}
+	}
+
+	var issues []Issue
+	for _, message := range summariseProblems(trace.Problems) {
+		issues = append(issues, fileError(file, message))
+	}
+	return issues
+}
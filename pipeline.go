@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+	"github.com/jfkonecn/data-transport-phenomena/history"
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// pipelineFlags registers the loading and analysis flags shared by the
+// aggregate, export and serve commands on fs. The returned function builds
+// the options once fs has been parsed.
+func pipelineFlags(fs *flag.FlagSet) func() (loader.Dir, analysis.Options, error) {
+	inputRoot := fs.String("input", "results/sort", "results `dir`ectory containing cpu/ and memory/")
+	resultFilter := filterFlags(fs)
+	outlierPolicy := outlierFlags(fs)
+	var bootstrap stats.BootstrapConfig
+	fs.Float64Var(&bootstrap.Level, "ci-level", 0.95, "confidence level for bootstrap intervals")
+	fs.IntVar(&bootstrap.Resamples, "bootstrap", 1000, "number of bootstrap resamples")
+	fs.Int64Var(&bootstrap.Seed, "seed", 1, "random seed for bootstrap resampling")
+	alpha := fs.Float64("alpha", 0.05, "significance level for pairwise algorithm comparisons")
+	clockDrift := fs.Float64("clock-drift", 0.02, "tolerated relative drift of CPU clock estimates within a run name")
+	baselineRun := fs.String("baseline-run", "", "run name used as the baseline for run comparisons (default first run name)")
+	unitFlag := fs.String("unit", "cycles", "unit for the CPU summary and charts: cycles, ns, ms, cycles-per-byte, bytes-per-second or cycles-per-nlogn")
+	predictSizesFlag := fs.String("predict-sizes", "1M,10M", "comma separated input sizes to extrapolate complexity fits to")
+	historyPath := fs.String("history", "", "history archive read for trends and, by aggregate, recorded into, such as "+history.DefaultPath+" (default none)")
+	trendThreshold := fs.Float64("trend-threshold", 0.05, "smallest relative shift in average cycles reported as a change point")
+
+	return func() (loader.Dir, analysis.Options, error) {
+		filter, err := resultFilter()
+		if err != nil {
+			return loader.Dir{}, analysis.Options{}, err
+		}
+		policy, err := outlierPolicy()
+		if err != nil {
+			return loader.Dir{}, analysis.Options{}, err
+		}
+		if err := bootstrap.Validate(); err != nil {
+			return loader.Dir{}, analysis.Options{}, err
+		}
+		unit, err := stats.LookupCPUUnit(*unitFlag)
+		if err != nil {
+			return loader.Dir{}, analysis.Options{}, err
+		}
+		predictSizes, err := parsePredictSizes(*predictSizesFlag)
+		if err != nil {
+			return loader.Dir{}, analysis.Options{}, err
+		}
+
+		dir := loader.Dir{
+			Root:      *inputRoot,
+			Filter:    filter,
+			Policy:    policy,
+			Bootstrap: bootstrap,
+		}
+		options := analysis.Options{
+			Unit:           unit,
+			Alpha:          *alpha,
+			ClockDrift:     *clockDrift,
+			BaselineRun:    *baselineRun,
+			PredictSizes:   predictSizes,
+			HistoryPath:    *historyPath,
+			TrendThreshold: *trendThreshold,
+		}
+		return dir, options, nil
+	}
+}
+
+// parsePredictSizes parses a comma separated list of sizes like "1M,10M"
+func parsePredictSizes(value string) ([]int, error) {
+	var sizes []int
+	for _, part := range splitList(value) {
+		size, err := loader.ParseSize(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prediction size %q: %w", part, err)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
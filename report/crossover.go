@@ -0,0 +1,62 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// CrossoverHeaders are the columns shared by the Crossovers sheet and the
+// crossover CSV
+var CrossoverHeaders = []string{"Run Name", "Faster Below", "Faster Above", "Crossover Size (bytes)", "Range Low (bytes)", "Range High (bytes)", "Bracket Low (bytes)", "Bracket High (bytes)"}
+
+// CrossoverValues returns the cells of one crossover in CrossoverHeaders order
+func CrossoverValues(c stats.Crossover) []interface{} {
+	return []interface{}{
+		c.RunName,
+		c.FasterBelow,
+		c.FasterAbove,
+		c.SizeBytes,
+		c.RangeLowBytes,
+		c.RangeHighBytes,
+		c.BracketLowBytes,
+		c.BracketHighBytes,
+	}
+}
+
+// writeCrossoverCSV writes crossovers with the same columns as the sheet
+func writeCrossoverCSV(filename string, crossovers []stats.Crossover) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(CrossoverHeaders); err != nil {
+		return fmt.Errorf("error writing %s: %w", filename, err)
+	}
+	for _, c := range crossovers {
+		record := []string{
+			c.RunName,
+			c.FasterBelow,
+			c.FasterAbove,
+			strconv.FormatFloat(c.SizeBytes, 'f', 0, 64),
+			strconv.FormatFloat(c.RangeLowBytes, 'f', 0, 64),
+			strconv.FormatFloat(c.RangeHighBytes, 'f', 0, 64),
+			strconv.Itoa(c.BracketLowBytes),
+			strconv.Itoa(c.BracketHighBytes),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing %s: %w", filename, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing %s: %w", filename, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+)
+
+// EncodeJSON returns report as indented JSON stamped with the schema
+// version
+func EncodeJSON(report analysis.Report) ([]byte, error) {
+	report.SchemaVersion = analysis.SchemaVersion
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding JSON report: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// writeJSON writes report to path as indented JSON
+func writeJSON(path string, report analysis.Report) error {
+	data, err := EncodeJSON(report)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing JSON report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// reportTable is a titled table of preformatted cells
+type reportTable struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// reportSection groups the charts and tables shown under one heading
+type reportSection struct {
+	Title  string
+	Charts []string
+	Tables []reportTable
+}
+
+// buildReportSections lays out the statistics shared by the Markdown and
+// HTML reports, with CPU values in the given unit
+func buildReportSections(report analysis.Report, unit stats.CPUUnit) []reportSection {
+	overview := reportSection{
+		Title: "Overview",
+		Tables: []reportTable{{
+			Headers: []string{"Setting", "Value"},
+			Rows: [][]string{
+				{"Generated", report.GeneratedAt.Format(time.RFC3339)},
+				{"Git Commit", report.GitCommit},
+				{"Host", report.Metadata.Hostname},
+				{"CPU Results", report.Metadata.CPUDir},
+				{"Memory Results", report.Metadata.MemoryDir},
+				{"Unit", unit.Label},
+				{"Outlier Policy", fmt.Sprintf("%s (threshold %g, %d warmup runs)", report.Metadata.OutlierPolicy.Method, report.Metadata.OutlierPolicy.Threshold, report.Metadata.OutlierPolicy.WarmupRuns)},
+				{"Confidence Level", fmt.Sprintf("%g%% (%d bootstrap resamples)", report.Metadata.Bootstrap.Level*100, report.Metadata.Bootstrap.Resamples)},
+			},
+		}},
+	}
+	sections := []reportSection{overview, scalingSection(report, unit)}
+
+	// One section per algorithm
+	algorithms := make(map[string]bool)
+	for _, stat := range report.CPUStats {
+		algorithms[stat.Algorithm] = true
+	}
+	for _, stat := range report.MemoryStats {
+		algorithms[stat.Algorithm] = true
+	}
+	for _, algorithm := range stats.SortedKeys(algorithms) {
+		sections = append(sections, algorithmSection(report, unit, algorithm))
+	}
+
+	sections = append(sections, findingsSection(report))
+	return sections
+}
+
+// scalingSection charts median CPU time and peak memory against input size
+// for every run name
+func scalingSection(report analysis.Report, unit stats.CPUUnit) reportSection {
+	section := reportSection{Title: "Scaling"}
+
+	cpuSeries := make(map[string]map[string]*svgSeries)
+	for _, stat := range report.CPUStats {
+		if stat.Count == 0 {
+			continue
+		}
+		addReportPoint(cpuSeries, stat.RunName, stat.Algorithm, float64(stat.FileSizeBytes), unit.Convert(stat.Median, stat))
+	}
+	memorySeries := make(map[string]map[string]*svgSeries)
+	for _, stat := range report.MemoryStats {
+		if !stat.Complete() {
+			continue
+		}
+		addReportPoint(memorySeries, stat.RunName, stat.Algorithm, float64(stat.FileSizeBytes), float64(stat.PeakMemoryUsage))
+	}
+
+	for _, run := range stats.SortedKeys(cpuSeries) {
+		section.Charts = append(section.Charts, svgLineChart{
+			Title:  fmt.Sprintf("Median %s on %s", unit.Label, run),
+			XLabel: "Input Size (bytes)",
+			YLabel: unit.Label,
+			LogX:   true,
+			LogY:   true,
+			Series: reportSeries(cpuSeries[run]),
+		}.render())
+	}
+	for _, run := range stats.SortedKeys(memorySeries) {
+		section.Charts = append(section.Charts, svgLineChart{
+			Title:  fmt.Sprintf("Peak Memory on %s", run),
+			XLabel: "Input Size (bytes)",
+			YLabel: "Peak Memory (bytes)",
+			LogX:   true,
+			LogY:   true,
+			Series: reportSeries(memorySeries[run]),
+		}.render())
+	}
+	return section
+}
+
+func addReportPoint(series map[string]map[string]*svgSeries, run, algorithm string, x, y float64) {
+	if series[run] == nil {
+		series[run] = make(map[string]*svgSeries)
+	}
+	s, ok := series[run][algorithm]
+	if !ok {
+		s = &svgSeries{Name: algorithm}
+		series[run][algorithm] = s
+	}
+	s.X = append(s.X, x)
+	s.Y = append(s.Y, y)
+}
+
+// reportSeries orders series by name so charts are stable across runs
+func reportSeries(series map[string]*svgSeries) []svgSeries {
+	result := make([]svgSeries, 0, len(series))
+	for _, name := range stats.SortedKeys(series) {
+		result = append(result, *series[name])
+	}
+	return result
+}
+
+func algorithmSection(report analysis.Report, unit stats.CPUUnit, algorithm string) reportSection {
+	section := reportSection{Title: algorithm}
+
+	cpu := reportTable{
+		Title:   "CPU",
+		Headers: []string{"Run", "File", "Size (bytes)", "Median", "Average", "CI Low", "CI High", "Std Dev", "CV", "Runs", "Rejected"},
+	}
+	for _, stat := range report.CPUStats {
+		if stat.Algorithm != algorithm {
+			continue
+		}
+		low, high := unit.ConvertRange(stat.MeanCILow, stat.MeanCIHigh, stat)
+		stdDev := "-"
+		if spread, ok := unit.ConvertSpread(stat.StdDev, stat); ok {
+			stdDev = formatReportNumber(spread)
+		}
+		cpu.Rows = append(cpu.Rows, []string{
+			stat.RunName,
+			stat.File,
+			strconv.Itoa(stat.FileSizeBytes),
+			formatReportNumber(unit.Convert(stat.Median, stat)),
+			formatReportNumber(unit.Convert(stat.Average, stat)),
+			formatReportNumber(low),
+			formatReportNumber(high),
+			stdDev,
+			fmt.Sprintf("%.3f", stat.CV),
+			strconv.Itoa(stat.Count),
+			strconv.Itoa(len(stat.Rejected)),
+		})
+	}
+	if len(cpu.Rows) > 0 {
+		cpu.Title = fmt.Sprintf("CPU %s", unit.Label)
+		section.Tables = append(section.Tables, cpu)
+	}
+
+	memory := reportTable{
+		Title:   "Memory",
+		Headers: []string{"Run", "File", "Size (bytes)", "Total Allocated", "Total Freed", "Peak", "Final", "Allocations", "Frees", "Status"},
+	}
+	for _, stat := range report.MemoryStats {
+		if stat.Algorithm != algorithm {
+			continue
+		}
+		memory.Rows = append(memory.Rows, []string{
+			stat.RunName,
+			stat.File,
+			strconv.Itoa(stat.FileSizeBytes),
+			strconv.FormatInt(stat.TotalAllocated, 10),
+			strconv.FormatInt(stat.TotalFreed, 10),
+			strconv.FormatInt(stat.PeakMemoryUsage, 10),
+			strconv.FormatInt(stat.FinalMemoryUsage, 10),
+			strconv.Itoa(stat.AllocationCount),
+			strconv.Itoa(stat.FreeCount),
+			stat.Status,
+		})
+	}
+	if len(memory.Rows) > 0 {
+		section.Tables = append(section.Tables, memory)
+	}
+
+	return section
+}
+
+// findingsSection lists significant differences, crossovers and diagnostic
+// issues
+func findingsSection(report analysis.Report) reportSection {
+	section := reportSection{Title: "Findings"}
+
+	significance := reportTable{
+		Title:   "Significant Differences",
+		Headers: []string{"Run", "File", "Algorithms", "Verdict", "p-value", "Cliff's Delta"},
+	}
+	for _, c := range report.Analysis.Significance {
+		if c.Winner == "" {
+			continue
+		}
+		significance.Rows = append(significance.Rows, []string{
+			c.RunName,
+			c.File,
+			c.AlgorithmA + " vs " + c.AlgorithmB,
+			c.Verdict,
+			fmt.Sprintf("%.3g", c.MannWhitneyP),
+			fmt.Sprintf("%.3f", c.CliffsDelta),
+		})
+	}
+
+	crossovers := reportTable{
+		Title:   "Crossovers",
+		Headers: []string{"Run", "Faster Below", "Faster Above", "Size (bytes)", "Range Low", "Range High"},
+	}
+	for _, c := range report.Analysis.Crossovers {
+		crossovers.Rows = append(crossovers.Rows, []string{
+			c.RunName,
+			c.FasterBelow,
+			c.FasterAbove,
+			formatReportNumber(c.SizeBytes),
+			formatReportNumber(c.RangeLowBytes),
+			formatReportNumber(c.RangeHighBytes),
+		})
+	}
+
+	issues := reportTable{
+		Title:   "Diagnostics",
+		Headers: []string{"Check", "Subject", "Issue"},
+	}
+	for _, d := range report.Diagnostics.Clock {
+		for _, issue := range d.Issues {
+			issues.Rows = append(issues.Rows, []string{"Clock", d.RunName, issue})
+		}
+	}
+	for _, m := range report.Diagnostics.MemoryIntegrity {
+		for _, issue := range m.Issues {
+			issues.Rows = append(issues.Rows, []string{"Memory", fmt.Sprintf("%s %s %s", m.Algorithm, m.RunName, m.File), issue})
+		}
+	}
+	for _, m := range report.MemoryStats {
+		if !m.Complete() {
+			issues.Rows = append(issues.Rows, []string{"Memory", fmt.Sprintf("%s %s %s", m.Algorithm, m.RunName, m.File), fmt.Sprintf("incomplete trace (%s)", m.Status)})
+		}
+	}
+	for _, c := range report.Diagnostics.ChangePoints {
+		issues.Rows = append(issues.Rows, []string{
+			"Trend",
+			fmt.Sprintf("%s %s %s", c.Algorithm, c.RunName, c.File),
+			fmt.Sprintf("shifted %+.1f%% at %s", c.ShiftChange*100, c.RecordID),
+		})
+	}
+
+	for _, table := range []reportTable{significance, crossovers, issues} {
+		if len(table.Rows) > 0 {
+			section.Tables = append(section.Tables, table)
+		}
+	}
+	return section
+}
+
+// formatReportNumber prints whole numbers without a fraction and keeps four
+// significant digits otherwise
+func formatReportNumber(v float64) string {
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return ""
+	case v == math.Trunc(v) && math.Abs(v) < 1e15:
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	case math.Abs(v) >= 1000:
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	default:
+		return strconv.FormatFloat(v, 'g', 4, 64)
+	}
+}
+
+func writeMarkdownReport(path string, report analysis.Report, unit stats.CPUUnit) error {
+	if err := os.WriteFile(path, []byte(RenderMarkdown(report, unit)), 0o644); err != nil {
+		return fmt.Errorf("error writing Markdown report %s: %w", path, err)
+	}
+	return nil
+}
+
+// RenderMarkdown returns the report as Markdown with inline SVG charts
+func RenderMarkdown(report analysis.Report, unit stats.CPUUnit) string {
+	var b strings.Builder
+	b.WriteString("# Sorting Benchmark Report\n")
+
+	for _, section := range buildReportSections(report, unit) {
+		fmt.Fprintf(&b, "\n## %s\n", section.Title)
+		for _, chart := range section.Charts {
+			b.WriteString("\n" + chart)
+		}
+		for _, table := range section.Tables {
+			b.WriteString("\n")
+			if table.Title != "" {
+				fmt.Fprintf(&b, "### %s\n\n", table.Title)
+			}
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(markdownCells(table.Headers), " | "))
+			fmt.Fprintf(&b, "|%s\n", strings.Repeat(" --- |", len(table.Headers)))
+			for _, row := range table.Rows {
+				fmt.Fprintf(&b, "| %s |\n", strings.Join(markdownCells(row), " | "))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// markdownCells escapes pipes so cell text cannot split a table column
+func markdownCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return escaped
+}
+
+// htmlReportStyle keeps the HTML report readable without external assets
+const htmlReportStyle = `body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+th { background: #f0f0f0; }
+td.number { text-align: right; }
+svg { display: block; margin: 1em 0; }`
+
+func writeHTMLReport(path string, report analysis.Report, unit stats.CPUUnit) error {
+	if err := os.WriteFile(path, []byte(RenderHTML(report, unit)), 0o644); err != nil {
+		return fmt.Errorf("error writing HTML report %s: %w", path, err)
+	}
+	return nil
+}
+
+// RenderHTML returns the report as a standalone HTML page
+func RenderHTML(report analysis.Report, unit stats.CPUUnit) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>Sorting Benchmark Report</title>\n")
+	fmt.Fprintf(&b, "<style>\n%s\n</style>\n", htmlReportStyle)
+	b.WriteString("</head>\n<body>\n<h1>Sorting Benchmark Report</h1>\n")
+
+	for _, section := range buildReportSections(report, unit) {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(section.Title))
+		for _, chart := range section.Charts {
+			b.WriteString(chart)
+		}
+		for _, table := range section.Tables {
+			if table.Title != "" {
+				fmt.Fprintf(&b, "<h3>%s</h3>\n", html.EscapeString(table.Title))
+			}
+			b.WriteString("<table>\n<tr>")
+			for _, header := range table.Headers {
+				fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(header))
+			}
+			b.WriteString("</tr>\n")
+			for _, row := range table.Rows {
+				b.WriteString("<tr>")
+				for _, cell := range row {
+					if _, err := strconv.ParseFloat(cell, 64); err == nil {
+						fmt.Fprintf(&b, "<td class=\"number\">%s</td>", cell)
+					} else {
+						fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(cell))
+					}
+				}
+				b.WriteString("</tr>\n")
+			}
+			b.WriteString("</table>\n")
+		}
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
@@ -0,0 +1,51 @@
+// Package report writes an aggregation as JSON, Markdown, HTML, SVG charts
+// or CSV. The Excel workbook is written by the xlsx subpackage.
+package report
+
+import (
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+)
+
+// Reporter writes an aggregation in one output format. Path is a file, or a
+// directory for formats made of several files such as SVG.
+type Reporter interface {
+	Write(path string, aggregation analysis.Aggregation) error
+}
+
+// JSON writes the report in the schema documented under "JSON Output" in the
+// README
+type JSON struct{}
+
+func (JSON) Write(path string, aggregation analysis.Aggregation) error {
+	return writeJSON(path, aggregation.Report)
+}
+
+// Markdown writes the report as Markdown with inline SVG charts
+type Markdown struct{}
+
+func (Markdown) Write(path string, aggregation analysis.Aggregation) error {
+	return writeMarkdownReport(path, aggregation.Report, aggregation.Options.Unit)
+}
+
+// HTML writes the report as a standalone HTML page
+type HTML struct{}
+
+func (HTML) Write(path string, aggregation analysis.Aggregation) error {
+	return writeHTMLReport(path, aggregation.Report, aggregation.Options.Unit)
+}
+
+// SVG writes one chart per algorithm, run and input into a directory
+type SVG struct{}
+
+func (SVG) Write(dir string, aggregation analysis.Aggregation) error {
+	report := aggregation.Report
+	return writeSVGCharts(dir, report.CPUStats, report.MemoryStats, aggregation.Options.Unit)
+}
+
+// CrossoverCSV writes the crossover table with the same columns as the
+// Crossovers sheet
+type CrossoverCSV struct{}
+
+func (CrossoverCSV) Write(path string, aggregation analysis.Aggregation) error {
+	return writeCrossoverCSV(path, aggregation.Report.Analysis.Crossovers)
+}
@@ -0,0 +1,337 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// Chart layout in SVG user units
+const (
+	svgWidth        = 640
+	svgHeight       = 400
+	svgMarginLeft   = 80
+	svgMarginRight  = 160
+	svgMarginTop    = 40
+	svgMarginBottom = 56
+)
+
+// svgMaxMarkers is the most points a line gets individual markers for
+const svgMaxMarkers = 50
+
+// svgPalette gives series fixed colours so output is stable across runs
+var svgPalette = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"}
+
+// svgSeries is one named line of an svgLineChart. When Low and High are set
+// they are drawn as a shaded error band around the line.
+type svgSeries struct {
+	Name string
+	X    []float64
+	Y    []float64
+	Low  []float64
+	High []float64
+}
+
+// svgLineChart plots series against linear or base 10 logarithmic axes
+type svgLineChart struct {
+	Title  string
+	XLabel string
+	YLabel string
+	LogX   bool
+	LogY   bool
+	Series []svgSeries
+}
+
+// svgAxis maps data values onto a pixel range
+type svgAxis struct {
+	Log      bool
+	Min, Max float64
+	From, To float64
+}
+
+func (a svgAxis) scale(v float64) float64 {
+	lo, hi := a.Min, a.Max
+	if a.Log {
+		v, lo, hi = math.Log10(v), math.Log10(lo), math.Log10(hi)
+	}
+	if hi == lo {
+		return (a.From + a.To) / 2
+	}
+	return a.From + (v-lo)/(hi-lo)*(a.To-a.From)
+}
+
+// svgTick is a labelled position on an axis
+type svgTick struct {
+	Value float64
+	Label string
+}
+
+// ticks returns powers of ten for log axes and round steps for linear axes
+func (a svgAxis) ticks() []svgTick {
+	var values []float64
+	if a.Log {
+		for e := math.Floor(math.Log10(a.Min)); e <= math.Ceil(math.Log10(a.Max)); e++ {
+			if v := math.Pow(10, e); v >= a.Min && v <= a.Max {
+				values = append(values, v)
+			}
+		}
+	} else if step := niceStep((a.Max - a.Min) / 5); step == 0 {
+		values = []float64{a.Min}
+	} else {
+		for v := math.Ceil(a.Min/step) * step; v <= a.Max+step*1e-9; v += step {
+			values = append(values, v)
+		}
+	}
+
+	ticks := make([]svgTick, len(values))
+	for i, v := range values {
+		ticks[i] = svgTick{Value: v, Label: svgTickLabel(v)}
+	}
+	return ticks
+}
+
+// niceStep rounds a raw tick step up to 1, 2 or 5 times a power of ten
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 0
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if raw <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// newSVGAxis spans values, dropping non-positive values from log axes and
+// padding the range of linear axes to start at zero
+func newSVGAxis(values []float64, log bool, from, to float64) svgAxis {
+	axis := svgAxis{Log: log, Min: math.Inf(1), Max: math.Inf(-1), From: from, To: to}
+	for _, v := range values {
+		if log && v <= 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		axis.Min = math.Min(axis.Min, v)
+		axis.Max = math.Max(axis.Max, v)
+	}
+	if math.IsInf(axis.Min, 1) {
+		axis.Min, axis.Max = 1, 10
+	}
+	if log {
+		axis.Min = math.Pow(10, math.Floor(math.Log10(axis.Min)))
+		axis.Max = math.Pow(10, math.Ceil(math.Log10(axis.Max)))
+		if axis.Min == axis.Max {
+			axis.Max *= 10
+		}
+		return axis
+	}
+	axis.Min = math.Min(axis.Min, 0)
+	if axis.Max == axis.Min {
+		axis.Max = axis.Min + 1
+	}
+	return axis
+}
+
+// render returns the chart as a standalone SVG element
+func (c svgLineChart) render() string {
+	var xs, ys []float64
+	for _, s := range c.Series {
+		xs = append(xs, s.X...)
+		ys = append(ys, s.Y...)
+		ys = append(ys, s.Low...)
+		ys = append(ys, s.High...)
+	}
+	x := newSVGAxis(xs, c.LogX, svgMarginLeft, svgWidth-svgMarginRight)
+	y := newSVGAxis(ys, c.LogY, svgHeight-svgMarginBottom, svgMarginTop)
+
+	var b strings.Builder
+	writeSVGFrame(&b, c.Title, c.XLabel, c.YLabel, x, y, x.ticks(), y.ticks())
+
+	// Bands go first so every line is drawn on top of them
+	for i, s := range c.Series {
+		if len(s.Low) != len(s.X) || len(s.High) != len(s.X) {
+			continue
+		}
+		var upper, lower []string
+		for j := range s.X {
+			if !svgPlottable(x, s.X[j]) || !svgPlottable(y, s.Low[j]) || !svgPlottable(y, s.High[j]) {
+				continue
+			}
+			upper = append(upper, svgPoint(x.scale(s.X[j]), y.scale(s.High[j])))
+			lower = append([]string{svgPoint(x.scale(s.X[j]), y.scale(s.Low[j]))}, lower...)
+		}
+		if len(upper) > 1 {
+			fmt.Fprintf(&b, `<polygon fill="%s" fill-opacity="0.2" stroke="none" points="%s"/>`+"\n", svgPalette[i%len(svgPalette)], strings.Join(append(upper, lower...), " "))
+		}
+	}
+
+	for i, s := range c.Series {
+		colour := svgPalette[i%len(svgPalette)]
+		var points []string
+		for j := range s.X {
+			if !svgPlottable(x, s.X[j]) || !svgPlottable(y, s.Y[j]) {
+				continue
+			}
+			points = append(points, svgPoint(x.scale(s.X[j]), y.scale(s.Y[j])))
+		}
+		if len(points) > 1 {
+			fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`+"\n", colour, strings.Join(points, " "))
+		}
+		if len(points) <= svgMaxMarkers {
+			for _, p := range points {
+				cx, cy, _ := strings.Cut(p, ",")
+				fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="3" fill="%s"/>`+"\n", cx, cy, colour)
+			}
+		}
+		writeSVGLegendEntry(&b, i, s.Name, colour)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// svgBox summarises one group of samples for an svgBoxChart
+type svgBox struct {
+	Label   string
+	Samples []float64
+}
+
+// svgBoxChart draws a box plot per group with Tukey whiskers at 1.5 IQR and
+// the samples beyond them as points
+type svgBoxChart struct {
+	Title  string
+	XLabel string
+	YLabel string
+	LogY   bool
+	Boxes  []svgBox
+}
+
+// render returns the chart as a standalone SVG element
+func (c svgBoxChart) render() string {
+	var ys []float64
+	for _, box := range c.Boxes {
+		ys = append(ys, box.Samples...)
+	}
+	x := svgAxis{Min: 0, Max: float64(max(len(c.Boxes), 1)), From: svgMarginLeft, To: svgWidth - svgMarginRight}
+	y := newSVGAxis(ys, c.LogY, svgHeight-svgMarginBottom, svgMarginTop)
+
+	xTicks := make([]svgTick, len(c.Boxes))
+	for i, box := range c.Boxes {
+		xTicks[i] = svgTick{Value: float64(i) + 0.5, Label: box.Label}
+	}
+
+	var b strings.Builder
+	writeSVGFrame(&b, c.Title, c.XLabel, c.YLabel, x, y, xTicks, y.ticks())
+
+	halfWidth := (x.To - x.From) / float64(max(len(c.Boxes), 1)) * 0.3
+	for i, box := range c.Boxes {
+		var values []float64
+		for _, v := range box.Samples {
+			if svgPlottable(y, v) {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		sort.Float64s(values)
+
+		colour := svgPalette[i%len(svgPalette)]
+		q1 := stats.Percentile(values, 25)
+		median := stats.Percentile(values, 50)
+		q3 := stats.Percentile(values, 75)
+		iqr := q3 - q1
+
+		// Whiskers reach the most extreme samples within 1.5 IQR
+		low, high := median, median
+		var outliers []float64
+		for _, v := range values {
+			if v < q1-1.5*iqr || v > q3+1.5*iqr {
+				outliers = append(outliers, v)
+				continue
+			}
+			low = math.Min(low, v)
+			high = math.Max(high, v)
+		}
+
+		cx := x.scale(float64(i) + 0.5)
+		left := svgNumber(cx - halfWidth)
+		right := svgNumber(cx + halfWidth)
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000000"/>`+"\n", svgNumber(cx), svgNumber(y.scale(low)), svgNumber(cx), svgNumber(y.scale(q1)))
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000000"/>`+"\n", svgNumber(cx), svgNumber(y.scale(q3)), svgNumber(cx), svgNumber(y.scale(high)))
+		for _, whisker := range []float64{low, high} {
+			fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000000"/>`+"\n", svgNumber(cx-halfWidth/2), svgNumber(y.scale(whisker)), svgNumber(cx+halfWidth/2), svgNumber(y.scale(whisker)))
+		}
+		fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s" fill-opacity="0.5" stroke="#000000"/>`+"\n", left, svgNumber(y.scale(q3)), svgNumber(2*halfWidth), svgNumber(y.scale(q1)-y.scale(q3)), colour)
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000000" stroke-width="2"/>`+"\n", left, svgNumber(y.scale(median)), right, svgNumber(y.scale(median)))
+		for _, v := range outliers {
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="2.5" fill="none" stroke="%s"/>`+"\n", svgNumber(cx), svgNumber(y.scale(v)), colour)
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// writeSVGFrame opens the svg element and draws the title, axes, grid lines
+// and tick labels
+func writeSVGFrame(b *strings.Builder, title, xLabel, yLabel string, x, y svgAxis, xTicks, yTicks []svgTick) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n", svgWidth, svgHeight, svgWidth, svgHeight)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", svgWidth, svgHeight)
+	fmt.Fprintf(b, `<text x="%d" y="24" text-anchor="middle" font-size="14" font-weight="bold">%s</text>`+"\n", (svgWidth-svgMarginRight+svgMarginLeft)/2, html.EscapeString(title))
+
+	for _, t := range yTicks {
+		py := svgNumber(y.scale(t.Value))
+		fmt.Fprintf(b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#e0e0e0"/>`+"\n", svgNumber(x.From), py, svgNumber(x.To), py)
+		fmt.Fprintf(b, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", svgNumber(x.From-6), py, html.EscapeString(t.Label))
+	}
+	for _, t := range xTicks {
+		px := svgNumber(x.scale(t.Value))
+		fmt.Fprintf(b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#e0e0e0"/>`+"\n", px, svgNumber(y.From), px, svgNumber(y.To))
+		fmt.Fprintf(b, `<text x="%s" y="%s" text-anchor="middle">%s</text>`+"\n", px, svgNumber(y.From+16), html.EscapeString(t.Label))
+	}
+
+	fmt.Fprintf(b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000000"/>`+"\n", svgNumber(x.From), svgNumber(y.From), svgNumber(x.To), svgNumber(y.From))
+	fmt.Fprintf(b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000000"/>`+"\n", svgNumber(x.From), svgNumber(y.From), svgNumber(x.From), svgNumber(y.To))
+	fmt.Fprintf(b, `<text x="%s" y="%d" text-anchor="middle">%s</text>`+"\n", svgNumber((x.From+x.To)/2), svgHeight-12, html.EscapeString(xLabel))
+	fmt.Fprintf(b, `<text x="16" y="%s" text-anchor="middle" transform="rotate(-90 16 %s)">%s</text>`+"\n", svgNumber((y.From+y.To)/2), svgNumber((y.From+y.To)/2), html.EscapeString(yLabel))
+}
+
+func writeSVGLegendEntry(b *strings.Builder, index int, name, colour string) {
+	top := svgMarginTop + index*18
+	left := svgWidth - svgMarginRight + 16
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`+"\n", left, top, colour)
+	fmt.Fprintf(b, `<text x="%d" y="%d" dominant-baseline="middle">%s</text>`+"\n", left+18, top+6, html.EscapeString(name))
+}
+
+func svgPlottable(axis svgAxis, v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0) && (!axis.Log || v > 0)
+}
+
+func svgPoint(x, y float64) string {
+	return svgNumber(x) + "," + svgNumber(y)
+}
+
+// svgNumber formats a coordinate with fixed precision so output is stable
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// svgTickLabel shortens large tick values with K, M and G suffixes
+func svgTickLabel(v float64) string {
+	for _, s := range []struct {
+		scale  float64
+		suffix string
+	}{{1e9, "G"}, {1e6, "M"}, {1e3, "K"}} {
+		if math.Abs(v) >= s.scale {
+			return strconv.FormatFloat(v/s.scale, 'g', 3, 64) + s.suffix
+		}
+	}
+	return strconv.FormatFloat(v, 'g', 3, 64)
+}
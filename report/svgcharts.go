@@ -0,0 +1,140 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// maxTracePoints caps the points drawn for a memory-over-time trace
+const maxTracePoints = 1000
+
+// unsafeFileChars matches characters kept out of chart file names
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// writeSVGCharts renders every chart as a separate SVG file in dir. Files are
+// named after what they plot and contain no timestamps, so unchanged results
+// give byte-identical charts.
+func writeSVGCharts(dir string, cpuStats []stats.CPUStats, memoryStats []stats.MemoryStats, unit stats.CPUUnit) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating chart directory: %w", err)
+	}
+
+	charts := RenderSVGCharts(cpuStats, memoryStats, unit)
+	for _, name := range stats.SortedKeys(charts) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(charts[name]), 0o644); err != nil {
+			return fmt.Errorf("error writing chart %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// RenderSVGCharts returns every chart keyed by its file name
+func RenderSVGCharts(cpuStats []stats.CPUStats, memoryStats []stats.MemoryStats, unit stats.CPUUnit) map[string]string {
+	charts := make(map[string]string)
+
+	// Log-log scaling curve per algorithm with a band for the median CI
+	scaling := make(map[string]map[string]*svgSeries)
+	boxes := make(map[string][]svgBox)
+	for _, stat := range cpuStats {
+		if stat.Count == 0 {
+			continue
+		}
+		if scaling[stat.Algorithm] == nil {
+			scaling[stat.Algorithm] = make(map[string]*svgSeries)
+		}
+		s, ok := scaling[stat.Algorithm][stat.RunName]
+		if !ok {
+			s = &svgSeries{Name: stat.RunName}
+			scaling[stat.Algorithm][stat.RunName] = s
+		}
+		low, high := unit.ConvertRange(stat.MedianCILow, stat.MedianCIHigh, stat)
+		s.X = append(s.X, float64(stat.FileSizeBytes))
+		s.Y = append(s.Y, unit.Convert(stat.Median, stat))
+		s.Low = append(s.Low, low)
+		s.High = append(s.High, high)
+
+		// Box plot of the kept samples per algorithm and run name
+		box := svgBox{Label: strings.TrimSuffix(stat.File, filepath.Ext(stat.File))}
+		for _, sample := range stat.Samples {
+			box.Samples = append(box.Samples, unit.Convert(float64(sample.Cycles), stat))
+		}
+		key := chartFileName("boxplot", stat.Algorithm, stat.RunName)
+		boxes[key] = append(boxes[key], box)
+	}
+	for _, algorithm := range stats.SortedKeys(scaling) {
+		charts[chartFileName("scaling", algorithm)] = svgLineChart{
+			Title:  fmt.Sprintf("%s Median %s", algorithm, unit.Label),
+			XLabel: "Input Size (bytes)",
+			YLabel: unit.Label,
+			LogX:   true,
+			LogY:   true,
+			Series: reportSeries(scaling[algorithm]),
+		}.render()
+	}
+	for _, stat := range cpuStats {
+		key := chartFileName("boxplot", stat.Algorithm, stat.RunName)
+		if _, ok := boxes[key]; !ok {
+			continue
+		}
+		charts[key] = svgBoxChart{
+			Title:  fmt.Sprintf("%s %s %s Samples", stat.Algorithm, stat.RunName, unit.Label),
+			XLabel: "Input File",
+			YLabel: unit.Label,
+			LogY:   true,
+			Boxes:  boxes[key],
+		}.render()
+	}
+
+	// Live memory over the events of every trace
+	for _, stat := range memoryStats {
+		if len(stat.MemorySamples) == 0 {
+			continue
+		}
+		title := fmt.Sprintf("%s %s %s Live Memory", stat.Algorithm, stat.RunName, stat.File)
+		if !stat.Complete() {
+			title += fmt.Sprintf(" (%s)", stat.Status)
+		}
+		x, y := downsampleTrace(stat.MemorySamples, maxTracePoints)
+		charts[chartFileName("memory", stat.Algorithm, stat.RunName, strings.TrimSuffix(stat.File, filepath.Ext(stat.File)))] = svgLineChart{
+			Title:  title,
+			XLabel: "Allocator Event",
+			YLabel: "Live Memory (bytes)",
+			Series: []svgSeries{{Name: "live bytes", X: x, Y: y}},
+		}.render()
+	}
+
+	return charts
+}
+
+// chartFileName joins the parts of a chart name into a safe .svg file name
+func chartFileName(parts ...string) string {
+	for i, part := range parts {
+		parts[i] = unsafeFileChars.ReplaceAllString(part, "-")
+	}
+	return strings.Join(parts, "_") + ".svg"
+}
+
+// downsampleTrace keeps the largest sample of each bucket so peaks survive,
+// returning 1-based event indices and live bytes
+func downsampleTrace(samples []int64, maxPoints int) ([]float64, []float64) {
+	bucket := (len(samples) + maxPoints - 1) / maxPoints
+	var x, y []float64
+	for start := 0; start < len(samples); start += bucket {
+		end := min(start+bucket, len(samples))
+		peak := start
+		for i := start + 1; i < end; i++ {
+			if samples[i] > samples[peak] {
+				peak = i
+			}
+		}
+		x = append(x, float64(peak+1))
+		y = append(y, float64(samples[peak]))
+	}
+	return x, y
+}
@@ -0,0 +1,54 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeClockDiagnosticsSheet(f *excelize.File, diagnostics []stats.ClockDiagnostic) error {
+	// Create clock diagnostics sheet
+	sheetName := "Clock Diagnostics"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating clock diagnostics sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Run Name", "Files", "Samples", "Mean Clock (Hz)", "Min Clock (Hz)", "Max Clock (Hz)", "Spread (%)", "Status", "Issues"}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, d := range diagnostics {
+		row := i + 2
+		status := "OK"
+		if len(d.Issues) > 0 {
+			status = "CHECK"
+		}
+		values := []interface{}{
+			d.RunName,
+			d.Files,
+			d.Samples,
+			d.MeanHz,
+			d.MinHz,
+			d.MaxHz,
+			d.SpreadPercent,
+			status,
+			strings.Join(d.Issues, "; "),
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 18); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeComplexitySheet(f *excelize.File, fits []stats.ComplexityFit, predictSizes []int) error {
+	// Create complexity sheet
+	sheetName := "Complexity"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating complexity sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Metric", "Algorithm", "Run Name", "Sizes Fitted"}
+	for _, model := range stats.ComplexityModels {
+		headers = append(headers, "R² "+model.Name)
+	}
+	headers = append(headers, "Power Law Coefficient", "Power Law Exponent", "Power Law R²", "Best Model")
+	for _, size := range predictSizes {
+		headers = append(headers, fmt.Sprintf("Predicted @ %d bytes", size))
+	}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, fit := range fits {
+		row := i + 2
+		values := []interface{}{fit.Metric, fit.Algorithm, fit.RunName, fit.Points}
+		for j := range stats.ComplexityModels {
+			if j < len(fit.Fits) {
+				values = append(values, fit.Fits[j].RSquared)
+			} else {
+				values = append(values, "")
+			}
+		}
+		values = append(values, fit.PowerCoefficient, fit.PowerExponent, fit.PowerRSquared, fit.BestModel)
+		for _, prediction := range fit.Predictions {
+			values = append(values, prediction.Value)
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 18); err != nil {
+		return err
+	}
+
+	return nil
+}
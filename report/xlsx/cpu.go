@@ -0,0 +1,346 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeCPUSheet(f *excelize.File, cpuStats []stats.CPUStats, unit stats.CPUUnit, logScale bool) error {
+	// Create CPU sheet
+	sheetName := "CPU Statistics"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating CPU sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Algorithm", "Run Name", "File", "File Size (bytes)", "Average Cycles", "Std Dev", "Min Cycles", "Max Cycles", "Sample Count", "Median Cycles", "P5 Cycles", "P25 Cycles", "P75 Cycles", "P95 Cycles", "P99 Cycles", "IQR", "MAD", "Coefficient of Variation", "Raw Sample Count", "Rejected Count", "Rejected Runs", "Mean CI Low", "Mean CI High", "Median CI Low", "Median CI High", "CPU Clock (Hz)"}
+	for _, unit := range stats.CPUUnits[1:] {
+		headers = append(headers, "Average "+unit.Label)
+	}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, stat := range cpuStats {
+		row := i + 2
+		values := []interface{}{
+			stat.Algorithm,
+			stat.RunName,
+			stat.File,
+			stat.FileSizeBytes,
+			stat.Average,
+			stat.StdDev,
+			stat.Min,
+			stat.Max,
+			stat.Count,
+			stat.Median,
+			stat.P5,
+			stat.P25,
+			stat.P75,
+			stat.P95,
+			stat.P99,
+			stat.IQR,
+			stat.MAD,
+			stat.CV,
+			stat.RawCount,
+			len(stat.Rejected),
+			stats.FormatRejected(stat.Rejected),
+			stat.MeanCILow,
+			stat.MeanCIHigh,
+			stat.MedianCILow,
+			stat.MedianCIHigh,
+			stat.CPUClockHz,
+		}
+		for _, unit := range stats.CPUUnits[1:] {
+			values = append(values, unit.Convert(stat.Average, stat))
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 15); err != nil {
+		return err
+	}
+
+	// Summarise in the selected unit for the charts
+	summarySheetName := "CPU Summary"
+	if err := writeCPUSummarySheet(f, summarySheetName, cpuStats, unit); err != nil {
+		return err
+	}
+
+	// Create charts
+	if err := createCPUCharts(f, summarySheetName, cpuStats, unit, logScale); err != nil {
+		return fmt.Errorf("error creating CPU charts: %w", err)
+	}
+
+	return nil
+}
+
+func createCPUCharts(f *excelize.File, sheetName string, cpuStats []stats.CPUStats, unit stats.CPUUnit, logScale bool) error {
+	if len(cpuStats) == 0 {
+		return nil
+	}
+
+	// Create a chart sheet for CPU performance comparison
+	chartSheetName := "CPU Charts"
+	_, err := f.NewSheet(chartSheetName)
+	if err != nil {
+		return fmt.Errorf("error creating CPU chart sheet: %w", err)
+	}
+
+	// Create performance comparison chart
+	chartName := "CPU Performance Comparison"
+	chart := &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       "Average " + unit.Label,
+				Categories: sheetRange(sheetName, summaryFileSizeColumn, len(cpuStats)),
+				Values:     sheetRange(sheetName, summaryAverageColumn, len(cpuStats)),
+			},
+		},
+		Title: excelize.ChartTitle{
+			Name: chartName,
+		},
+	}
+
+	// Overlay the mean confidence interval as an error range
+	meanCI := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       "Mean CI Low",
+				Categories: sheetRange(sheetName, summaryFileSizeColumn, len(cpuStats)),
+				Values:     sheetRange(sheetName, summaryMeanCILowColumn, len(cpuStats)),
+			},
+			{
+				Name:       "Mean CI High",
+				Categories: sheetRange(sheetName, summaryFileSizeColumn, len(cpuStats)),
+				Values:     sheetRange(sheetName, summaryMeanCIHighColumn, len(cpuStats)),
+			},
+		},
+	}
+
+	// Add chart to the sheet
+	if err := f.AddChart(chartSheetName, "A1", chart, meanCI); err != nil {
+		return fmt.Errorf("error adding CPU chart: %w", err)
+	}
+
+	// Create median chart with its confidence interval
+	medianChart := &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       "Median " + unit.Label,
+				Categories: sheetRange(sheetName, summaryFileSizeColumn, len(cpuStats)),
+				Values:     sheetRange(sheetName, summaryMedianColumn, len(cpuStats)),
+			},
+		},
+		Title: excelize.ChartTitle{
+			Name: "CPU Median with Confidence Interval",
+		},
+	}
+	medianCI := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       "Median CI Low",
+				Categories: sheetRange(sheetName, summaryFileSizeColumn, len(cpuStats)),
+				Values:     sheetRange(sheetName, summaryMedianCILowColumn, len(cpuStats)),
+			},
+			{
+				Name:       "Median CI High",
+				Categories: sheetRange(sheetName, summaryFileSizeColumn, len(cpuStats)),
+				Values:     sheetRange(sheetName, summaryMedianCIHighColumn, len(cpuStats)),
+			},
+		},
+	}
+	if err := f.AddChart(chartSheetName, "A16", medianChart, medianCI); err != nil {
+		return fmt.Errorf("error adding CPU median chart: %w", err)
+	}
+
+	// Create algorithm comparison chart
+	if err := createAlgorithmComparisonChart(f, chartSheetName, cpuStats, unit, logScale, "K1"); err != nil {
+		return fmt.Errorf("error creating algorithm comparison chart: %w", err)
+	}
+
+	return nil
+}
+
+// comparisonDataColumn is where createAlgorithmComparisonChart writes its
+// helper data range, to the right of the charts on the same sheet
+const comparisonDataColumn = 21
+
+// createAlgorithmComparisonChart plots one line per algorithm across input
+// sizes. The values are written to a helper range on sheetName first, with a
+// row per file size and a column per algorithm, because chart series must
+// reference cells. Algorithms measured on several run names get a series per
+// run name.
+func createAlgorithmComparisonChart(f *excelize.File, sheetName string, cpuStats []stats.CPUStats, unit stats.CPUUnit, logScale bool, position string) error {
+	// Collect the sizes and series in a deterministic order
+	sizeSet := make(map[int]bool)
+	values := make(map[string]map[int]float64)
+	runs := make(map[string]bool)
+	for _, stat := range cpuStats {
+		if stat.Count == 0 {
+			continue
+		}
+		key := stat.Algorithm + " (" + stat.RunName + ")"
+		if values[key] == nil {
+			values[key] = make(map[int]float64)
+		}
+		values[key][stat.FileSizeBytes] = unit.Convert(stat.Average, stat)
+		sizeSet[stat.FileSizeBytes] = true
+		runs[stat.RunName] = true
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	sizes := make([]int, 0, len(sizeSet))
+	for size := range sizeSet {
+		sizes = append(sizes, size)
+	}
+	sort.Ints(sizes)
+	seriesKeys := stats.SortedKeys(values)
+
+	// Write the helper data range
+	headers := []string{"File Size (bytes)"}
+	for _, key := range seriesKeys {
+		if len(runs) == 1 {
+			key, _, _ = strings.Cut(key, " (")
+		}
+		headers = append(headers, key)
+	}
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(comparisonDataColumn+i, 1)
+		if err != nil {
+			return fmt.Errorf("error resolving comparison header cell: %w", err)
+		}
+		if err := f.SetCellValue(sheetName, cell, header); err != nil {
+			return fmt.Errorf("error setting comparison header %s: %w", header, err)
+		}
+	}
+	for row, size := range sizes {
+		cell, err := excelize.CoordinatesToCellName(comparisonDataColumn, row+2)
+		if err != nil {
+			return fmt.Errorf("error resolving comparison size cell: %w", err)
+		}
+		if err := f.SetCellValue(sheetName, cell, size); err != nil {
+			return fmt.Errorf("error setting comparison size %d: %w", size, err)
+		}
+		for i, key := range seriesKeys {
+			value, ok := values[key][size]
+			// Missing sizes, and values a log axis cannot show, are left blank
+			if !ok || logScale && value <= 0 {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(comparisonDataColumn+i+1, row+2)
+			if err != nil {
+				return fmt.Errorf("error resolving comparison value cell: %w", err)
+			}
+			if err := f.SetCellValue(sheetName, cell, value); err != nil {
+				return fmt.Errorf("error setting comparison value for %s: %w", key, err)
+			}
+		}
+	}
+
+	// Create a chart comparing algorithms
+	chart := &excelize.Chart{
+		Type:   excelize.Line,
+		Series: []excelize.ChartSeries{},
+		Title: excelize.ChartTitle{
+			Name: fmt.Sprintf("Algorithm Performance Comparison (Average %s)", unit.Label),
+		},
+	}
+	if logScale {
+		chart.YAxis.LogBase = 10
+	}
+
+	// Add series for each algorithm, named from its header cell
+	for i := range seriesKeys {
+		column, err := excelize.ColumnNumberToName(comparisonDataColumn + i + 1)
+		if err != nil {
+			return fmt.Errorf("error resolving comparison column: %w", err)
+		}
+		chart.Series = append(chart.Series, excelize.ChartSeries{
+			Name:       fmt.Sprintf("'%s'!$%s$1", sheetName, column),
+			Categories: sheetRange(sheetName, comparisonDataColumn, len(sizes)),
+			Values:     sheetRange(sheetName, comparisonDataColumn+i+1, len(sizes)),
+		})
+	}
+
+	// Add chart to the sheet
+	if err := f.AddChart(sheetName, position, chart); err != nil {
+		return fmt.Errorf("error adding algorithm comparison chart: %w", err)
+	}
+
+	return nil
+}
+
+// Columns of the CPU summary sheet referenced by the CPU charts
+const (
+	summaryFileSizeColumn     = 4
+	summaryAverageColumn      = 5
+	summaryMedianColumn       = 6
+	summaryMeanCILowColumn    = 9
+	summaryMeanCIHighColumn   = 10
+	summaryMedianCILowColumn  = 11
+	summaryMedianCIHighColumn = 12
+)
+
+// writeCPUSummarySheet writes the headline CPU statistics in the selected unit
+func writeCPUSummarySheet(f *excelize.File, sheetName string, cpuStats []stats.CPUStats, unit stats.CPUUnit) error {
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating CPU summary sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Algorithm", "Run Name", "File", "File Size (bytes)"}
+	for _, name := range []string{"Average", "Median", "Min", "Max", "Mean CI Low", "Mean CI High", "Median CI Low", "Median CI High"} {
+		headers = append(headers, fmt.Sprintf("%s (%s)", name, unit.Label))
+	}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, stat := range cpuStats {
+		row := i + 2
+		minimum, maximum := unit.ConvertRange(float64(stat.Min), float64(stat.Max), stat)
+		meanLow, meanHigh := unit.ConvertRange(stat.MeanCILow, stat.MeanCIHigh, stat)
+		medianLow, medianHigh := unit.ConvertRange(stat.MedianCILow, stat.MedianCIHigh, stat)
+		values := []interface{}{
+			stat.Algorithm,
+			stat.RunName,
+			stat.File,
+			stat.FileSizeBytes,
+			unit.Convert(stat.Average, stat),
+			unit.Convert(stat.Median, stat),
+			minimum,
+			maximum,
+			meanLow,
+			meanHigh,
+			medianLow,
+			medianHigh,
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 18); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/jfkonecn/data-transport-phenomena/report"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeCrossoverSheet(f *excelize.File, crossovers []stats.Crossover) error {
+	// Create crossover sheet
+	sheetName := "Crossovers"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating crossover sheet: %w", err)
+	}
+
+	// Write headers
+	if err := writeHeaderRow(f, sheetName, report.CrossoverHeaders); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, c := range crossovers {
+		if err := writeDataRow(f, sheetName, i+2, report.CrossoverHeaders, report.CrossoverValues(c)); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(report.CrossoverHeaders), 18); err != nil {
+		return err
+	}
+
+	return nil
+}
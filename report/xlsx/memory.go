@@ -0,0 +1,176 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeMemorySheet(f *excelize.File, memoryStats []stats.MemoryStats) error {
+	// Create Memory sheet
+	sheetName := "Memory Statistics"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating memory sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Algorithm", "Run Name", "File", "File Size (bytes)", "Total Allocated (bytes)", "Total Freed (bytes)", "Average Memory Usage (bytes)", "Allocation Count", "Free Count", "Total Resized (bytes)", "Resize Count", "Total Remapped (bytes)", "Remap Count", "Peak Memory Usage (bytes)", "Peak Event Index", "Final Memory Usage (bytes)", "P50 Memory Usage (bytes)", "P90 Memory Usage (bytes)", "P95 Memory Usage (bytes)", "P99 Memory Usage (bytes)", "Status"}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, stat := range memoryStats {
+		row := i + 2
+		values := []interface{}{
+			stat.Algorithm,
+			stat.RunName,
+			stat.File,
+			stat.FileSizeBytes,
+			stat.TotalAllocated,
+			stat.TotalFreed,
+			stat.AverageMemoryUsage,
+			stat.AllocationCount,
+			stat.FreeCount,
+			stat.TotalResized,
+			stat.ResizeCount,
+			stat.TotalRemapped,
+			stat.RemapCount,
+			stat.PeakMemoryUsage,
+			stat.PeakEventIndex,
+			stat.FinalMemoryUsage,
+			stat.P50MemoryUsage,
+			stat.P90MemoryUsage,
+			stat.P95MemoryUsage,
+			stat.P99MemoryUsage,
+			stat.Status,
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 20); err != nil {
+		return err
+	}
+
+	// Create charts
+	if err := createMemoryCharts(f, sheetName, memoryStats); err != nil {
+		return fmt.Errorf("error creating memory charts: %w", err)
+	}
+
+	return nil
+}
+
+func writeMemoryIntegritySheet(f *excelize.File, results []stats.MemoryIntegrity) error {
+	// Create Memory Integrity sheet
+	sheetName := "Memory Integrity"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating memory integrity sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Algorithm", "Run Name", "File", "File Size (bytes)", "Status", "Live Bytes At End", "Net Bytes (live at end - over-freed)", "Over-Freed Bytes", "Unmatched Allocations", "Unmatched Frees", "Alloc/Free Count Imbalance", "Issues"}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, integrity := range results {
+		row := i + 2
+		status := "OK"
+		if len(integrity.Issues) > 0 {
+			status = "IMBALANCED"
+		}
+		values := []interface{}{
+			integrity.Algorithm,
+			integrity.RunName,
+			integrity.File,
+			integrity.FileSizeBytes,
+			status,
+			integrity.LiveBytesAtEnd,
+			integrity.NetBytes,
+			integrity.OverFreedBytes,
+			integrity.UnmatchedAllocs,
+			integrity.UnmatchedFrees,
+			integrity.CountImbalance,
+			strings.Join(integrity.Issues, "; "),
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 20); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createMemoryCharts(f *excelize.File, sheetName string, memoryStats []stats.MemoryStats) error {
+	if len(memoryStats) == 0 {
+		return nil
+	}
+
+	// Create a chart sheet for memory analysis
+	chartSheetName := "Memory Charts"
+	_, err := f.NewSheet(chartSheetName)
+	if err != nil {
+		return fmt.Errorf("error creating memory chart sheet: %w", err)
+	}
+
+	// Create memory usage chart
+	chartName := "Memory Usage Analysis"
+	chart := &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       "Total Allocated",
+				Categories: "Memory Statistics!$D$2:$D$" + fmt.Sprintf("%d", len(memoryStats)+1),
+				Values:     "Memory Statistics!$E$2:$E$" + fmt.Sprintf("%d", len(memoryStats)+1),
+			},
+			{
+				Name:       "Total Freed",
+				Categories: "Memory Statistics!$D$2:$D$" + fmt.Sprintf("%d", len(memoryStats)+1),
+				Values:     "Memory Statistics!$F$2:$F$" + fmt.Sprintf("%d", len(memoryStats)+1),
+			},
+		},
+		Title: excelize.ChartTitle{
+			Name: chartName,
+		},
+	}
+
+	// Add chart to the sheet
+	if err := f.AddChart(chartSheetName, "A1", chart); err != nil {
+		return fmt.Errorf("error adding memory chart: %w", err)
+	}
+
+	// Create average memory usage chart
+	avgChart := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{
+				Name:       "Average Memory Usage",
+				Categories: "Memory Statistics!$D$2:$D$" + fmt.Sprintf("%d", len(memoryStats)+1),
+				Values:     "Memory Statistics!$G$2:$G$" + fmt.Sprintf("%d", len(memoryStats)+1),
+			},
+		},
+		Title: excelize.ChartTitle{
+			Name: "Average Memory Usage by File Size",
+		},
+	}
+
+	// Add average memory chart
+	if err := f.AddChart(chartSheetName, "K1", avgChart); err != nil {
+		return fmt.Errorf("error adding average memory chart: %w", err)
+	}
+
+	return nil
+}
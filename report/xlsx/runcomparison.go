@@ -0,0 +1,88 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeRunComparisonSheet(f *excelize.File, comparisons []stats.RunComparison, runs []string, baseline string, unit stats.CPUUnit) error {
+	// Create run comparison sheet
+	sheetName := "Run Comparison"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating run comparison sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Algorithm", "File", "File Size (bytes)"}
+	for _, run := range runs {
+		headers = append(headers, fmt.Sprintf("%s Median (%s)", run, unit.Label))
+	}
+	for _, run := range runs {
+		if run != baseline {
+			headers = append(headers, fmt.Sprintf("%s Speedup vs %s", run, baseline))
+		}
+	}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, c := range comparisons {
+		row := i + 2
+		values := []interface{}{c.Algorithm, c.File, c.FileSizeBytes}
+		for _, run := range runs {
+			values = append(values, optionalValue(c.Medians, run))
+		}
+		for _, run := range runs {
+			if run != baseline {
+				values = append(values, optionalValue(c.Speedups, run))
+			}
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 18); err != nil {
+		return err
+	}
+
+	// Create one clustered chart per algorithm, with a series per run name
+	chartColumn, err := excelize.ColumnNumberToName(len(headers) + 2)
+	if err != nil {
+		return fmt.Errorf("error resolving chart column: %w", err)
+	}
+	chartRow := 1
+	for start := 0; start < len(comparisons); {
+		end := start
+		for end < len(comparisons) && comparisons[end].Algorithm == comparisons[start].Algorithm {
+			end++
+		}
+
+		chart := &excelize.Chart{
+			Type: excelize.Col,
+			Title: excelize.ChartTitle{
+				Name: fmt.Sprintf("%s by Run Name", comparisons[start].Algorithm),
+			},
+		}
+		for i, run := range runs {
+			chart.Series = append(chart.Series, excelize.ChartSeries{
+				Name:       run,
+				Categories: rowRange(sheetName, 3, start+2, end+1),
+				Values:     rowRange(sheetName, 4+i, start+2, end+1),
+			})
+		}
+		if err := f.AddChart(sheetName, fmt.Sprintf("%s%d", chartColumn, chartRow), chart); err != nil {
+			return fmt.Errorf("error adding run comparison chart for %s: %w", comparisons[start].Algorithm, err)
+		}
+
+		chartRow += 16
+		start = end
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// writeHeaderRow writes column headers into the first row of a sheet
+func writeHeaderRow(f *excelize.File, sheetName string, headers []string) error {
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return fmt.Errorf("error resolving cell for header %s: %w", header, err)
+		}
+		if err := f.SetCellValue(sheetName, cell, header); err != nil {
+			return fmt.Errorf("error setting header %s: %w", header, err)
+		}
+	}
+	return nil
+}
+
+// writeDataRow writes one value per header into the given row of a sheet
+func writeDataRow(f *excelize.File, sheetName string, row int, headers []string, values []interface{}) error {
+	for i, value := range values {
+		cell, err := excelize.CoordinatesToCellName(i+1, row)
+		if err != nil {
+			return fmt.Errorf("error resolving cell for %s in row %d: %w", headers[i], row, err)
+		}
+		if err := f.SetCellValue(sheetName, cell, value); err != nil {
+			return fmt.Errorf("error setting %s for row %d: %w", strings.ToLower(headers[i]), row, err)
+		}
+	}
+	return nil
+}
+
+// setColumnWidths gives the first count columns of a sheet the same width
+func setColumnWidths(f *excelize.File, sheetName string, count int, width float64) error {
+	for i := 1; i <= count; i++ {
+		col, err := excelize.ColumnNumberToName(i)
+		if err != nil {
+			return fmt.Errorf("error resolving column %d: %w", i, err)
+		}
+		if err := f.SetColWidth(sheetName, col, col, width); err != nil {
+			return fmt.Errorf("error setting column width for %s: %w", col, err)
+		}
+	}
+	return nil
+}
+
+// sheetRange returns an absolute reference to the data rows of one column,
+// assuming a single header row followed by count rows
+func sheetRange(sheetName string, column, count int) string {
+	name, err := excelize.ColumnNumberToName(column)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("'%s'!$%s$2:$%s$%d", sheetName, name, name, count+1)
+}
+
+// optionalValue returns the value for key, or an empty cell when missing
+func optionalValue(values map[string]float64, key string) interface{} {
+	if value, ok := values[key]; ok {
+		return value
+	}
+	return ""
+}
+
+// rowRange returns an absolute reference to rows first..last of one column
+func rowRange(sheetName string, column, first, last int) string {
+	name, err := excelize.ColumnNumberToName(column)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("'%s'!$%s$%d:$%s$%d", sheetName, name, first, name, last)
+}
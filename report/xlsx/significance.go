@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeSignificanceSheet(f *excelize.File, comparisons []stats.PairwiseComparison) error {
+	// Create significance sheet
+	sheetName := "Significance Tests"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating significance sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Run Name", "File", "File Size (bytes)", "Algorithm A", "Algorithm B", "Samples A", "Samples B", "Median A", "Median B", "Mann-Whitney U", "Mann-Whitney p", "Welch t", "Welch df", "Welch p", "Cliff's Delta", "Effect Size", "Hedges' g", "Winner", "Verdict"}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, c := range comparisons {
+		row := i + 2
+		values := []interface{}{
+			c.RunName,
+			c.File,
+			c.FileSizeBytes,
+			c.AlgorithmA,
+			c.AlgorithmB,
+			c.CountA,
+			c.CountB,
+			c.MedianA,
+			c.MedianB,
+			c.MannWhitneyU,
+			c.MannWhitneyP,
+			c.WelchT,
+			c.WelchDF,
+			c.WelchP,
+			c.CliffsDelta,
+			c.EffectSize,
+			c.HedgesG,
+			c.Winner,
+			c.Verdict,
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 15); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package xlsx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jfkonecn/data-transport-phenomena/history"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeTrendsSheet(f *excelize.File, records []history.Record, series []history.TrendSeries) error {
+	// Create trends sheet
+	sheetName := "Trends"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating trends sheet: %w", err)
+	}
+
+	// One row per aggregation, one column per series
+	headers := []string{"Record ID", "Timestamp", "Git Commit"}
+	for _, s := range series {
+		headers = append(headers, fmt.Sprintf("%s %s %s", s.Algorithm, s.RunName, s.File))
+	}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	rowByID := make(map[string]int, len(records))
+	for i, record := range records {
+		row := i + 2
+		rowByID[record.ID] = row
+		values := []interface{}{record.ID, record.Timestamp.Format(time.RFC3339), history.ShortCommit(record.GitCommit)}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+	for i, s := range series {
+		column := i + 4
+		for j, id := range s.RecordIDs {
+			cell, err := excelize.CoordinatesToCellName(column, rowByID[id])
+			if err != nil {
+				return fmt.Errorf("error resolving cell for %s: %w", headers[column-1], err)
+			}
+			if err := f.SetCellValue(sheetName, cell, s.Averages[j]); err != nil {
+				return fmt.Errorf("error setting %s for record %s: %w", headers[column-1], id, err)
+			}
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 22); err != nil {
+		return err
+	}
+
+	// Create one line chart per algorithm with a series per run and file
+	chartColumn, err := excelize.ColumnNumberToName(len(headers) + 2)
+	if err != nil {
+		return fmt.Errorf("error resolving chart column: %w", err)
+	}
+	chartRow := 1
+	for start := 0; start < len(series); {
+		end := start
+		for end < len(series) && series[end].Algorithm == series[start].Algorithm {
+			end++
+		}
+
+		chart := &excelize.Chart{
+			Type: excelize.Line,
+			Title: excelize.ChartTitle{
+				Name: fmt.Sprintf("%s Average Cycles over Time", series[start].Algorithm),
+			},
+		}
+		for i := start; i < end; i++ {
+			chart.Series = append(chart.Series, excelize.ChartSeries{
+				Name:       fmt.Sprintf("%s %s", series[i].RunName, series[i].File),
+				Categories: rowRange(sheetName, 1, 2, len(records)+1),
+				Values:     rowRange(sheetName, i+4, 2, len(records)+1),
+			})
+		}
+		if err := f.AddChart(sheetName, fmt.Sprintf("%s%d", chartColumn, chartRow), chart); err != nil {
+			return fmt.Errorf("error adding trend chart for %s: %w", series[start].Algorithm, err)
+		}
+
+		chartRow += 16
+		start = end
+	}
+
+	return nil
+}
+
+func writeChangePointsSheet(f *excelize.File, changes []history.ChangePoint) error {
+	// Create change points sheet
+	sheetName := "Change Points"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("error creating change points sheet: %w", err)
+	}
+
+	// Write headers
+	headers := []string{"Algorithm", "Run Name", "File", "Record ID", "Timestamp", "Git Commit", "Mean Before", "Mean After", "Change"}
+	if err := writeHeaderRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, c := range changes {
+		row := i + 2
+		values := []interface{}{
+			c.Algorithm,
+			c.RunName,
+			c.File,
+			c.RecordID,
+			c.Timestamp.Format(time.RFC3339),
+			c.GitCommit,
+			c.BeforeMean,
+			c.AfterMean,
+			c.ShiftChange,
+		}
+		if err := writeDataRow(f, sheetName, row, headers, values); err != nil {
+			return err
+		}
+	}
+
+	// Auto-size columns
+	if err := setColumnWidths(f, sheetName, len(headers), 18); err != nil {
+		return err
+	}
+
+	return nil
+}
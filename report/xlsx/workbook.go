@@ -0,0 +1,78 @@
+// Package xlsx writes an aggregation as an Excel workbook with a sheet and
+// native charts per analysis.
+package xlsx
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+	"github.com/xuri/excelize/v2"
+)
+
+// Workbook writes the aggregation as an Excel workbook. LogScale switches
+// the algorithm comparison chart to a logarithmic value axis.
+type Workbook struct {
+	LogScale bool
+}
+
+func (w Workbook) Write(path string, aggregation analysis.Aggregation) error {
+	return writeWorkbook(path, aggregation, w.LogScale)
+}
+
+// writeWorkbook builds the Excel workbook with a sheet per analysis
+func writeWorkbook(path string, aggregation analysis.Aggregation, logScale bool) error {
+	report := aggregation.Report
+	unit := aggregation.Options.Unit
+
+	// Create Excel file
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	// Delete the default "Sheet1" that gets created
+	if err := f.DeleteSheet("Sheet1"); err != nil {
+		log.Printf("Warning: could not delete default Sheet1: %v", err)
+	}
+
+	if err := writeCPUSheet(f, report.CPUStats, unit, logScale); err != nil {
+		return fmt.Errorf("error writing CPU sheet: %w", err)
+	}
+	if err := writeClockDiagnosticsSheet(f, report.Diagnostics.Clock); err != nil {
+		return fmt.Errorf("error writing clock diagnostics sheet: %w", err)
+	}
+	if err := writeRunComparisonSheet(f, report.Analysis.RunComparison, aggregation.RunNames, report.Analysis.BaselineRun, unit); err != nil {
+		return fmt.Errorf("error writing run comparison sheet: %w", err)
+	}
+	if err := writeSignificanceSheet(f, report.Analysis.Significance); err != nil {
+		return fmt.Errorf("error writing significance sheet: %w", err)
+	}
+	if err := writeMemorySheet(f, report.MemoryStats); err != nil {
+		return fmt.Errorf("error writing memory sheet: %w", err)
+	}
+	if err := writeMemoryIntegritySheet(f, report.Diagnostics.MemoryIntegrity); err != nil {
+		return fmt.Errorf("error writing memory integrity sheet: %w", err)
+	}
+	if err := writeCrossoverSheet(f, report.Analysis.Crossovers); err != nil {
+		return fmt.Errorf("error writing crossover sheet: %w", err)
+	}
+	if err := writeComplexitySheet(f, report.Analysis.Complexity, aggregation.Options.PredictSizes); err != nil {
+		return fmt.Errorf("error writing complexity sheet: %w", err)
+	}
+	if aggregation.History != nil {
+		if err := writeTrendsSheet(f, aggregation.History, aggregation.Trends); err != nil {
+			return fmt.Errorf("error writing trends sheet: %w", err)
+		}
+		if err := writeChangePointsSheet(f, report.Diagnostics.ChangePoints); err != nil {
+			return fmt.Errorf("error writing change points sheet: %w", err)
+		}
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("error saving workbook %s: %w", path, err)
+	}
+	return nil
+}
@@ -2,6 +2,11 @@
 
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 
-cd "$SCRIPT_DIR"/..
+# Build into a temporary directory so the aggregator runs from the caller's
+# directory and relative -input and -output paths resolve from there
+BUILD_DIR="$(mktemp -d)"
+trap 'rm -rf "$BUILD_DIR"' EXIT
 
-go run aggregate_data.go
+(cd "$SCRIPT_DIR"/.. && go build -o "$BUILD_DIR/aggregate-data" .) || exit 1
+
+"$BUILD_DIR/aggregate-data" "$@"
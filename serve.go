@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/jfkonecn/data-transport-phenomena/analysis"
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/report"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+// runServe implements the serve command: a local dashboard that reloads the
+// results on every request, so new benchmark runs show up on refresh
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	pipeline := pipelineFlags(fs)
+	addr := fs.String("addr", "localhost:8080", "`address` to listen on")
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, "serve [options]",
+			"Serves the HTML report at /, the JSON report at /report.json, the Markdown\nreport at /report.md and the SVG charts under /charts/.")
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err)
+	}
+
+	dir, options, err := pipeline()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	// Check the results load before listening
+	if _, err := dir.Load(); err != nil {
+		log.Printf("Error loading results: %v", err)
+		return 1
+	}
+
+	log.Printf("Serving dashboard on http://%s/", *addr)
+	if err := http.ListenAndServe(*addr, newDashboard(dir, options)); err != nil {
+		log.Printf("Error serving dashboard: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// newDashboard returns the handler behind the serve command
+func newDashboard(source loader.Loader, options analysis.Options) http.Handler {
+	// aggregate reruns the pipeline so every page reflects the files on disk
+	aggregate := func(w http.ResponseWriter) (analysis.Aggregation, bool) {
+		results, err := source.Load()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return analysis.Aggregation{}, false
+		}
+		return analysis.Analyse(results, options), true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		aggregation, ok := aggregate(w)
+		if !ok {
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, report.RenderHTML(aggregation.Report, options.Unit))
+	})
+	mux.HandleFunc("/report.json", func(w http.ResponseWriter, r *http.Request) {
+		aggregation, ok := aggregate(w)
+		if !ok {
+			return
+		}
+		data, err := report.EncodeJSON(aggregation.Report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+	mux.HandleFunc("/report.md", func(w http.ResponseWriter, r *http.Request) {
+		aggregation, ok := aggregate(w)
+		if !ok {
+			return
+		}
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		fmt.Fprint(w, report.RenderMarkdown(aggregation.Report, options.Unit))
+	})
+	mux.HandleFunc("/charts/", func(w http.ResponseWriter, r *http.Request) {
+		aggregation, ok := aggregate(w)
+		if !ok {
+			return
+		}
+		charts := report.RenderSVGCharts(aggregation.Report.CPUStats, aggregation.Report.MemoryStats, options.Unit)
+
+		name := strings.TrimPrefix(r.URL.Path, "/charts/")
+		if name == "" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>Charts</title>\n</head>\n<body>\n<ul>\n")
+			for _, chart := range stats.SortedKeys(charts) {
+				fmt.Fprintf(w, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(chart), html.EscapeString(chart))
+			}
+			fmt.Fprint(w, "</ul>\n</body>\n</html>\n")
+			return
+		}
+
+		chart, ok := charts[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		fmt.Fprint(w, chart)
+	})
+	return mux
+}
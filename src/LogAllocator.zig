@@ -65,14 +65,16 @@ pub fn LogAllocator(comptime WriterType: type) type {
         fn resize(ctx: *anyopaque, mem: []u8, alignment: Alignment, new_len: usize, ret_addr: usize) bool {
             const self: *Self = @alignCast(@ptrCast(ctx));
             const result = self.base_allocator.rawResize(mem, alignment, new_len, ret_addr);
-            self.writeLog(alignment, "RESIZE", new_len);
+            // A failed resize changes nothing; the caller falls back to alloc and free
+            if (result) self.writeLog(alignment, "RESIZE", new_len);
             return result;
         }
 
         fn remap(ctx: *anyopaque, mem: []u8, alignment: Alignment, new_len: usize, ret_addr: usize) ?[*]u8 {
             const self: *Self = @alignCast(@ptrCast(ctx));
             const result = self.base_allocator.rawRemap(mem, alignment, new_len, ret_addr);
-            self.writeLog(alignment, "REMAP", new_len);
+            // A failed remap changes nothing; the caller falls back to alloc and free
+            if (result != null) self.writeLog(alignment, "REMAP", new_len);
             return result;
         }
 
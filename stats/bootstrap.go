@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+)
+
+// BootstrapConfig controls the resampling used for confidence intervals
+type BootstrapConfig struct {
+	Level     float64 `json:"level"`
+	Resamples int     `json:"resamples"`
+	Seed      int64   `json:"seed"`
+}
+
+// Validate checks the confidence level and resample count
+func (c BootstrapConfig) Validate() error {
+	if c.Level <= 0 || c.Level >= 1 {
+		return fmt.Errorf("confidence level must be between 0 and 1, got %g", c.Level)
+	}
+	if c.Resamples < 1 {
+		return fmt.Errorf("bootstrap resamples must be positive, got %d", c.Resamples)
+	}
+	return nil
+}
+
+// AddConfidenceIntervals fills in bootstrap confidence intervals for the mean
+// and median of every benchmark's kept samples. Each benchmark gets its own
+// generator derived from the seed, so results do not depend on stats order.
+func AddConfidenceIntervals(stats []CPUStats, config BootstrapConfig) {
+	for i := range stats {
+		stat := &stats[i]
+		if len(stat.Samples) == 0 {
+			continue
+		}
+
+		values := make([]float64, len(stat.Samples))
+		for j, d := range stat.Samples {
+			values[j] = float64(d.Cycles)
+		}
+
+		rng := rand.New(rand.NewSource(config.Seed ^ benchmarkSeed(stat.Algorithm, stat.RunName, stat.File)))
+		means, medians := bootstrapMeansAndMedians(values, config.Resamples, rng)
+
+		alpha := (1 - config.Level) / 2
+		stat.MeanCILow = Percentile(means, alpha*100)
+		stat.MeanCIHigh = Percentile(means, (1-alpha)*100)
+		stat.MedianCILow = Percentile(medians, alpha*100)
+		stat.MedianCIHigh = Percentile(medians, (1-alpha)*100)
+	}
+}
+
+// bootstrapMeansAndMedians resamples values with replacement and returns the
+// sorted means and medians of each resample
+func bootstrapMeansAndMedians(values []float64, resamples int, rng *rand.Rand) ([]float64, []float64) {
+	means := make([]float64, resamples)
+	medians := make([]float64, resamples)
+	resample := make([]float64, len(values))
+
+	for r := 0; r < resamples; r++ {
+		var sum float64
+		for i := range resample {
+			resample[i] = values[rng.Intn(len(values))]
+			sum += resample[i]
+		}
+		sort.Float64s(resample)
+		means[r] = sum / float64(len(resample))
+		medians[r] = Percentile(resample, 50)
+	}
+
+	sort.Float64s(means)
+	sort.Float64s(medians)
+	return means, medians
+}
+
+// benchmarkSeed hashes a benchmark's identity into a seed offset
+func benchmarkSeed(algorithm, runName, file string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(algorithm + "_" + runName + "_" + file))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// minTrendSegment is the fewest aggregations on each side of a change point
+const minTrendSegment = 2
+
+// DetectChangePoints finds level shifts with binary segmentation: each
+// segment is split where the CUSUM of deviations from its mean peaks, which
+// is where the squared error reduction is largest. A split is kept when its
+// error reduction beats a BIC style penalty from the series noise and the
+// means on either side differ by at least minShift. Returned indices are the
+// first value of each new segment, in ascending order.
+func DetectChangePoints(values []float64, minShift float64) []int {
+	if len(values) < 2*minTrendSegment {
+		return nil
+	}
+
+	penalty := 2 * seriesNoiseVariance(values) * math.Log(float64(len(values)))
+
+	var points []int
+	var split func(lo, hi int)
+	split = func(lo, hi int) {
+		if hi-lo < 2*minTrendSegment {
+			return
+		}
+
+		total := squaredError(values[lo:hi])
+		bestIndex := -1
+		var bestGain float64
+		for k := lo + minTrendSegment; k <= hi-minTrendSegment; k++ {
+			gain := total - squaredError(values[lo:k]) - squaredError(values[k:hi])
+			if gain > bestGain {
+				bestGain = gain
+				bestIndex = k
+			}
+		}
+		if bestIndex < 0 || bestGain <= penalty {
+			return
+		}
+
+		before := Mean(values[lo:bestIndex])
+		after := Mean(values[bestIndex:hi])
+		if math.Abs(RelativeChange(before, after)) < minShift {
+			return
+		}
+
+		points = append(points, bestIndex)
+		split(lo, bestIndex)
+		split(bestIndex, hi)
+	}
+	split(0, len(values))
+
+	sort.Ints(points)
+	return points
+}
+
+// squaredError returns the sum of squared deviations from the mean
+func squaredError(values []float64) float64 {
+	m := Mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return sum
+}
+
+// seriesNoiseVariance estimates the noise variance from the MAD of first
+// differences, which is not inflated by the level shifts being searched for
+func seriesNoiseVariance(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	diffs := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		diffs[i-1] = values[i] - values[i-1]
+	}
+	sort.Float64s(diffs)
+	sigma := madScale * MedianAbsoluteDeviation(diffs, Percentile(diffs, 50))
+	return sigma * sigma / 2
+}
+
+// RelativeChange returns (candidate - baseline) / baseline. Growth from zero
+// counts as a full 100% increase so it cannot hide behind a division by zero.
+func RelativeChange(baseline, candidate float64) float64 {
+	if baseline == 0 {
+		if candidate == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (candidate - baseline) / baseline
+}
@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Plausible bounds for a cycle counter frequency estimate
+const (
+	minPlausibleClockHz = 1e6
+	maxPlausibleClockHz = 10e9
+)
+
+// ClockDiagnostic summarises the cpu_clock_hz estimates recorded for one run
+// name, which should all come from the same machine and counter
+type ClockDiagnostic struct {
+	RunName       string   `json:"run_name"`
+	Files         int      `json:"files"`
+	Samples       int      `json:"samples"`
+	MeanHz        float64  `json:"mean_hz"`
+	MinHz         float64  `json:"min_hz"`
+	MaxHz         float64  `json:"max_hz"`
+	SpreadPercent float64  `json:"spread_percent"`
+	Issues        []string `json:"issues"`
+}
+
+// CheckClockEstimates groups clock estimates by run name and flags values
+// that vary within a file, drift across files by more than driftTolerance
+// (a fraction of the run median), or fall outside plausible bounds
+func CheckClockEstimates(stats []CPUStats, driftTolerance float64) []ClockDiagnostic {
+	byRun := make(map[string][]CPUStats)
+	for _, stat := range stats {
+		byRun[stat.RunName] = append(byRun[stat.RunName], stat)
+	}
+
+	var diagnostics []ClockDiagnostic
+	for _, runName := range SortedKeys(byRun) {
+		diagnostic := ClockDiagnostic{RunName: runName}
+
+		var all []float64
+		fileClocks := make(map[string]float64)
+		var fileNames []string
+		for _, stat := range byRun[runName] {
+			clocks := sampleClocks(stat)
+			if len(clocks) == 0 {
+				continue
+			}
+			name := stat.Algorithm + " " + stat.File
+			diagnostic.Files++
+			diagnostic.Samples += len(clocks)
+			all = append(all, clocks...)
+			fileNames = append(fileNames, name)
+
+			sort.Float64s(clocks)
+			fileClocks[name] = Percentile(clocks, 50)
+
+			lowest, highest := clocks[0], clocks[len(clocks)-1]
+			if lowest <= 0 {
+				diagnostic.Issues = append(diagnostic.Issues, fmt.Sprintf("%s: zero clock estimate (is the cycle counter enabled?)", name))
+			} else if lowest < minPlausibleClockHz || highest > maxPlausibleClockHz {
+				diagnostic.Issues = append(diagnostic.Issues, fmt.Sprintf("%s: clock estimate outside %.0f MHz to %.0f GHz", name, minPlausibleClockHz/1e6, maxPlausibleClockHz/1e9))
+			}
+			if highest > 0 && (highest-lowest)/highest > driftTolerance {
+				diagnostic.Issues = append(diagnostic.Issues, fmt.Sprintf("%s: clock varies by %.2f%% within the file", name, (highest-lowest)/highest*100))
+			}
+		}
+		if len(all) == 0 {
+			continue
+		}
+
+		sort.Float64s(all)
+		diagnostic.MeanHz = Mean(all)
+		diagnostic.MinHz = all[0]
+		diagnostic.MaxHz = all[len(all)-1]
+		if diagnostic.MeanHz > 0 {
+			diagnostic.SpreadPercent = (diagnostic.MaxHz - diagnostic.MinHz) / diagnostic.MeanHz * 100
+		}
+
+		// Compare each file against the run median to find the drifting ones
+		runMedian := Percentile(all, 50)
+		sort.Strings(fileNames)
+		for _, name := range fileNames {
+			if runMedian <= 0 || fileClocks[name] <= 0 {
+				continue
+			}
+			deviation := (fileClocks[name] - runMedian) / runMedian
+			if math.Abs(deviation) > driftTolerance {
+				diagnostic.Issues = append(diagnostic.Issues, fmt.Sprintf("%s: clock %.0f Hz drifts %+.2f%% from run median", name, fileClocks[name], deviation*100))
+			}
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// sampleClocks returns the clock estimate of every sample, kept or rejected
+func sampleClocks(stat CPUStats) []float64 {
+	clocks := make([]float64, 0, len(stat.Samples)+len(stat.Rejected))
+	for _, d := range stat.Samples {
+		clocks = append(clocks, float64(d.CPUClockHz))
+	}
+	for _, r := range stat.Rejected {
+		clocks = append(clocks, float64(r.CPUClockHz))
+	}
+	return clocks
+}
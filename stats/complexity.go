@@ -0,0 +1,217 @@
+package stats
+
+import (
+	"log"
+	"math"
+	"sort"
+)
+
+// ComplexityModel is a candidate growth function fitted as y = a + b*g(n)
+type ComplexityModel struct {
+	Name   string
+	Growth func(n float64) float64
+}
+
+// ComplexityModels are fitted in this order, which ComplexityFit.Fits follows
+var ComplexityModels = []ComplexityModel{
+	{Name: "O(n)", Growth: func(n float64) float64 { return n }},
+	{Name: "O(n log n)", Growth: func(n float64) float64 { return n * math.Log2(n) }},
+	{Name: "O(n^2)", Growth: func(n float64) float64 { return n * n }},
+}
+
+// ModelFit is the least squares fit of one complexity model
+type ModelFit struct {
+	Model     string  `json:"model"`
+	Intercept float64 `json:"intercept"`
+	Slope     float64 `json:"slope"`
+	RSquared  float64 `json:"r_squared"`
+}
+
+// Prediction is a metric value extrapolated to an unmeasured input size
+type Prediction struct {
+	SizeBytes int     `json:"size_bytes"`
+	Value     float64 `json:"value"`
+}
+
+// ComplexityFit describes how one metric of an algorithm/run series grows
+// with input size
+type ComplexityFit struct {
+	Metric           string       `json:"metric"`
+	Algorithm        string       `json:"algorithm"`
+	RunName          string       `json:"run_name"`
+	Points           int          `json:"points"`
+	Fits             []ModelFit   `json:"fits"`
+	PowerCoefficient float64      `json:"power_coefficient"`
+	PowerExponent    float64      `json:"power_exponent"`
+	PowerRSquared    float64      `json:"power_r_squared"`
+	BestModel        string       `json:"best_model"`
+	Predictions      []Prediction `json:"predictions"`
+}
+
+// complexityPoint is one measured input size of a metric series
+type complexityPoint struct {
+	SizeBytes float64
+	Value     float64
+}
+
+// minComplexityPoints is the fewest distinct sizes a series needs to be fitted
+const minComplexityPoints = 3
+
+// FitCPUComplexity fits median cycles against input size for every
+// algorithm/run series
+func FitCPUComplexity(stats []CPUStats, predictSizes []int) []ComplexityFit {
+	series := make(map[[2]string][]complexityPoint)
+	for _, stat := range stats {
+		if stat.Count == 0 {
+			continue
+		}
+		key := [2]string{stat.Algorithm, stat.RunName}
+		series[key] = append(series[key], complexityPoint{SizeBytes: float64(stat.FileSizeBytes), Value: stat.Median})
+	}
+	return fitSeries("Median Cycles", series, predictSizes)
+}
+
+// FitMemoryComplexity fits peak and total allocated bytes against input size
+// for every algorithm/run series, ignoring incomplete traces
+func FitMemoryComplexity(stats []MemoryStats, predictSizes []int) []ComplexityFit {
+	peak := make(map[[2]string][]complexityPoint)
+	allocated := make(map[[2]string][]complexityPoint)
+	for _, stat := range stats {
+		if !stat.Complete() {
+			continue
+		}
+		key := [2]string{stat.Algorithm, stat.RunName}
+		peak[key] = append(peak[key], complexityPoint{SizeBytes: float64(stat.FileSizeBytes), Value: float64(stat.PeakMemoryUsage)})
+		allocated[key] = append(allocated[key], complexityPoint{SizeBytes: float64(stat.FileSizeBytes), Value: float64(stat.TotalAllocated)})
+	}
+	fits := fitSeries("Peak Memory (bytes)", peak, predictSizes)
+	return append(fits, fitSeries("Total Allocated (bytes)", allocated, predictSizes)...)
+}
+
+func fitSeries(metric string, series map[[2]string][]complexityPoint, predictSizes []int) []ComplexityFit {
+	keys := make([][2]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	var fits []ComplexityFit
+	for _, key := range keys {
+		points := series[key]
+		if countDistinctSizes(points) < minComplexityPoints {
+			log.Printf("Warning: not enough input sizes to fit %s for %s_%s", metric, key[0], key[1])
+			continue
+		}
+		fit := fitComplexity(points, predictSizes)
+		fit.Metric = metric
+		fit.Algorithm = key[0]
+		fit.RunName = key[1]
+		fits = append(fits, fit)
+	}
+	return fits
+}
+
+// fitComplexity fits each complexity model and a free power law to points
+// and predicts the best model at the requested sizes
+func fitComplexity(points []complexityPoint, predictSizes []int) ComplexityFit {
+	fit := ComplexityFit{Points: len(points)}
+
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+
+	// A flat series, such as an algorithm that never allocates, is constant
+	if SampleVariance(values) == 0 {
+		fit.BestModel = "O(1)"
+		for _, size := range predictSizes {
+			fit.Predictions = append(fit.Predictions, Prediction{SizeBytes: size, Value: values[0]})
+		}
+		return fit
+	}
+
+	var best ModelFit
+	var bestModel ComplexityModel
+	for i, model := range ComplexityModels {
+		x := make([]float64, len(points))
+		for j, p := range points {
+			x[j] = model.Growth(p.SizeBytes)
+		}
+		intercept, slope, r2 := linearFit(x, values)
+		modelFit := ModelFit{Model: model.Name, Intercept: intercept, Slope: slope, RSquared: r2}
+		fit.Fits = append(fit.Fits, modelFit)
+		if i == 0 || r2 > best.RSquared {
+			best = modelFit
+			bestModel = model
+		}
+	}
+	fit.BestModel = best.Model
+
+	// Fit log(y) = log(c) + k*log(n) over positive values only
+	var logX, logY []float64
+	for _, p := range points {
+		if p.Value > 0 && p.SizeBytes > 0 {
+			logX = append(logX, math.Log(p.SizeBytes))
+			logY = append(logY, math.Log(p.Value))
+		}
+	}
+	if len(logX) >= minComplexityPoints {
+		intercept, slope, r2 := linearFit(logX, logY)
+		fit.PowerCoefficient = math.Exp(intercept)
+		fit.PowerExponent = slope
+		fit.PowerRSquared = r2
+	}
+
+	for _, size := range predictSizes {
+		value := best.Intercept + best.Slope*bestModel.Growth(float64(size))
+		fit.Predictions = append(fit.Predictions, Prediction{SizeBytes: size, Value: value})
+	}
+
+	return fit
+}
+
+// linearFit returns the ordinary least squares intercept, slope and
+// coefficient of determination of y against x
+func linearFit(x, y []float64) (float64, float64, float64) {
+	meanX := Mean(x)
+	meanY := Mean(y)
+
+	var sxx, sxy, syy float64
+	for i := range x {
+		dx := x[i] - meanX
+		dy := y[i] - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return meanY, 0, 0
+	}
+
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	var ssRes float64
+	for i := range x {
+		residual := y[i] - (intercept + slope*x[i])
+		ssRes += residual * residual
+	}
+	r2 := float64(1)
+	if syy > 0 {
+		r2 = 1 - ssRes/syy
+	}
+	return intercept, slope, r2
+}
+
+func countDistinctSizes(points []complexityPoint) int {
+	sizes := make(map[float64]bool)
+	for _, p := range points {
+		sizes[p.SizeBytes] = true
+	}
+	return len(sizes)
+}
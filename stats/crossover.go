@@ -0,0 +1,161 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Crossover is an input size where two algorithms swap places. The estimate
+// comes from log-log interpolation of the measured medians; the range comes
+// from interpolating the bootstrap median confidence bounds, and is open
+// ended at the smallest or largest measured size when a bound never crosses.
+type Crossover struct {
+	RunName          string  `json:"run_name"`
+	FasterBelow      string  `json:"faster_below"`
+	FasterAbove      string  `json:"faster_above"`
+	SizeBytes        float64 `json:"size_bytes"`
+	RangeLowBytes    float64 `json:"range_low_bytes"`
+	RangeHighBytes   float64 `json:"range_high_bytes"`
+	BracketLowBytes  int     `json:"bracket_low_bytes"`
+	BracketHighBytes int     `json:"bracket_high_bytes"`
+}
+
+// crossoverPoint is one measured size of an algorithm's median curve
+type crossoverPoint struct {
+	SizeBytes int
+	Median    float64
+	Low       float64
+	High      float64
+}
+
+// FindCrossovers reports every size bracket where the faster of two
+// algorithms on the same run changes
+func FindCrossovers(stats []CPUStats) []Crossover {
+	curves := make(map[string]map[string][]crossoverPoint)
+	for _, stat := range stats {
+		if stat.Count == 0 || stat.Median <= 0 {
+			continue
+		}
+		if curves[stat.RunName] == nil {
+			curves[stat.RunName] = make(map[string][]crossoverPoint)
+		}
+		curves[stat.RunName][stat.Algorithm] = append(curves[stat.RunName][stat.Algorithm], crossoverPoint{
+			SizeBytes: stat.FileSizeBytes,
+			Median:    stat.Median,
+			Low:       stat.MedianCILow,
+			High:      stat.MedianCIHigh,
+		})
+	}
+
+	var crossovers []Crossover
+	for _, runName := range SortedKeys(curves) {
+		algorithms := SortedKeys(curves[runName])
+		for i := 0; i < len(algorithms); i++ {
+			for j := i + 1; j < len(algorithms); j++ {
+				a, b := algorithms[i], algorithms[j]
+				for _, c := range crossCurves(curves[runName][a], curves[runName][b], a, b) {
+					c.RunName = runName
+					crossovers = append(crossovers, c)
+				}
+			}
+		}
+	}
+	return crossovers
+}
+
+func crossCurves(curveA, curveB []crossoverPoint, nameA, nameB string) []Crossover {
+	// Align both curves on the sizes they share
+	pointsB := make(map[int]crossoverPoint)
+	for _, p := range curveB {
+		pointsB[p.SizeBytes] = p
+	}
+	var sizes []int
+	var a, b []crossoverPoint
+	sort.Slice(curveA, func(i, j int) bool { return curveA[i].SizeBytes < curveA[j].SizeBytes })
+	for _, p := range curveA {
+		if q, ok := pointsB[p.SizeBytes]; ok {
+			sizes = append(sizes, p.SizeBytes)
+			a = append(a, p)
+			b = append(b, q)
+		}
+	}
+
+	var crossovers []Crossover
+	for k := 0; k+1 < len(sizes); k++ {
+		d0 := math.Log(a[k].Median) - math.Log(b[k].Median)
+		d1 := math.Log(a[k+1].Median) - math.Log(b[k+1].Median)
+		if d0 == 0 || d0*d1 > 0 {
+			continue
+		}
+
+		// Orient the curves so that x is the algorithm faster below the crossing
+		x, y := a, b
+		crossover := Crossover{FasterBelow: nameA, FasterAbove: nameB}
+		if d0 > 0 {
+			x, y = b, a
+			crossover.FasterBelow, crossover.FasterAbove = nameB, nameA
+		}
+		crossover.SizeBytes = interpolateCrossing(sizes[k], sizes[k+1], d0, d1)
+		crossover.BracketLowBytes = sizes[k]
+		crossover.BracketHighBytes = sizes[k+1]
+
+		// The earliest plausible crossing pairs x's slow bound with y's fast
+		// bound, and the latest pairs x's fast bound with y's slow bound
+		early := make([]float64, len(sizes))
+		late := make([]float64, len(sizes))
+		for i := range sizes {
+			early[i] = logRatio(x[i].High, y[i].Low)
+			late[i] = logRatio(x[i].Low, y[i].High)
+		}
+		crossover.RangeLowBytes = math.Min(crossover.SizeBytes, risingCrossing(sizes, early, crossover.SizeBytes, float64(sizes[0])))
+		crossover.RangeHighBytes = math.Max(crossover.SizeBytes, risingCrossing(sizes, late, crossover.SizeBytes, float64(sizes[len(sizes)-1])))
+
+		crossovers = append(crossovers, crossover)
+	}
+	return crossovers
+}
+
+// logRatio returns log(x/y), or NaN when either value is not positive
+func logRatio(x, y float64) float64 {
+	if x <= 0 || y <= 0 {
+		return math.NaN()
+	}
+	return math.Log(x) - math.Log(y)
+}
+
+// risingCrossing returns the upward zero crossing of diffs nearest to the
+// estimate, or fallback when the series never crosses
+func risingCrossing(sizes []int, diffs []float64, estimate, fallback float64) float64 {
+	best := fallback
+	found := false
+	for k := 0; k+1 < len(sizes); k++ {
+		d0, d1 := diffs[k], diffs[k+1]
+		if math.IsNaN(d0) || math.IsNaN(d1) || d0 >= 0 || d1 < 0 {
+			continue
+		}
+		crossing := interpolateCrossing(sizes[k], sizes[k+1], d0, d1)
+		if !found || math.Abs(math.Log(crossing/estimate)) < math.Abs(math.Log(best/estimate)) {
+			best = crossing
+			found = true
+		}
+	}
+	return best
+}
+
+// interpolateCrossing finds where a log-ratio moving linearly in log size
+// from d0 at lo to d1 at hi reaches zero
+func interpolateCrossing(lo, hi int, d0, d1 float64) float64 {
+	t := d0 / (d0 - d1)
+	logSize := math.Log(float64(lo)) + t*(math.Log(float64(hi))-math.Log(float64(lo)))
+	return math.Exp(logSize)
+}
+
+// SortedKeys returns the keys of a string keyed map in ascending order
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
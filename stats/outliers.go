@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// OutlierMethod selects the fence used to reject CPU samples
+type OutlierMethod string
+
+const (
+	OutlierNone  OutlierMethod = "none"
+	OutlierTukey OutlierMethod = "tukey"
+	OutlierMAD   OutlierMethod = "mad"
+)
+
+// Default fence multipliers used when OutlierPolicy.Threshold is zero
+const (
+	defaultTukeyThreshold = 1.5
+	defaultMADThreshold   = 3.5
+)
+
+// madScale converts a median absolute deviation into a standard deviation
+// estimate for normally distributed data
+const madScale = 1.4826
+
+// OutlierPolicy decides which CPU samples are excluded before statistics are
+// calculated. Warm-up runs are dropped first, then the fence is applied to
+// the remaining samples.
+type OutlierPolicy struct {
+	WarmupRuns int           `json:"warmup_runs"`
+	Method     OutlierMethod `json:"method"`
+	Threshold  float64       `json:"threshold"`
+}
+
+// RejectedSample records a CPU sample excluded by the outlier policy
+type RejectedSample struct {
+	CPUData
+	Reason string `json:"reason"`
+}
+
+// ParseOutlierMethod accepts none, tukey or mad in any case
+func ParseOutlierMethod(value string) (OutlierMethod, error) {
+	switch method := OutlierMethod(strings.ToLower(value)); method {
+	case OutlierNone, OutlierTukey, OutlierMAD:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown outlier method %q (expected none, tukey or mad)", value)
+	}
+}
+
+// Validate checks that the warm-up count and fence multiplier are not negative
+func (p OutlierPolicy) Validate() error {
+	if p.WarmupRuns < 0 {
+		return fmt.Errorf("warm-up runs must not be negative, got %d", p.WarmupRuns)
+	}
+	if p.Threshold < 0 {
+		return fmt.Errorf("outlier threshold must not be negative, got %g", p.Threshold)
+	}
+	return nil
+}
+
+// threshold returns the configured fence multiplier or the method default
+func (p OutlierPolicy) threshold() float64 {
+	if p.Threshold > 0 {
+		return p.Threshold
+	}
+	if p.Method == OutlierMAD {
+		return defaultMADThreshold
+	}
+	return defaultTukeyThreshold
+}
+
+// Apply splits samples into those kept for statistics and those rejected,
+// with the reason for each rejection
+func (p OutlierPolicy) Apply(data []CPUData) ([]CPUData, []RejectedSample) {
+	ordered := make([]CPUData, len(data))
+	copy(ordered, data)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].RunNumber < ordered[j].RunNumber
+	})
+
+	var rejected []RejectedSample
+	warmup := max(0, min(p.WarmupRuns, len(ordered)))
+	for _, d := range ordered[:warmup] {
+		rejected = append(rejected, RejectedSample{CPUData: d, Reason: "warm-up run"})
+	}
+	remaining := ordered[warmup:]
+
+	if len(remaining) == 0 || p.Method == OutlierNone || p.Method == "" {
+		return remaining, rejected
+	}
+
+	sorted := make([]float64, len(remaining))
+	for i, d := range remaining {
+		sorted[i] = float64(d.Cycles)
+	}
+	sort.Float64s(sorted)
+	threshold := p.threshold()
+
+	var kept []CPUData
+	switch p.Method {
+	case OutlierTukey:
+		q1 := Percentile(sorted, 25)
+		q3 := Percentile(sorted, 75)
+		lower := q1 - threshold*(q3-q1)
+		upper := q3 + threshold*(q3-q1)
+		for _, d := range remaining {
+			cycles := float64(d.Cycles)
+			switch {
+			case cycles < lower:
+				rejected = append(rejected, RejectedSample{CPUData: d, Reason: fmt.Sprintf("below Tukey fence %.0f", lower)})
+			case cycles > upper:
+				rejected = append(rejected, RejectedSample{CPUData: d, Reason: fmt.Sprintf("above Tukey fence %.0f", upper)})
+			default:
+				kept = append(kept, d)
+			}
+		}
+	case OutlierMAD:
+		median := Percentile(sorted, 50)
+		scaledMAD := madScale * MedianAbsoluteDeviation(sorted, median)
+		for _, d := range remaining {
+			// With no spread every sample is equally typical
+			if scaledMAD == 0 {
+				kept = append(kept, d)
+				continue
+			}
+			score := math.Abs(float64(d.Cycles)-median) / scaledMAD
+			if score > threshold {
+				rejected = append(rejected, RejectedSample{CPUData: d, Reason: fmt.Sprintf("MAD score %.2f > %.2f", score, threshold)})
+			} else {
+				kept = append(kept, d)
+			}
+		}
+	}
+
+	return kept, rejected
+}
+
+// FormatRejected renders rejected samples as "run (reason)" pairs
+func FormatRejected(rejected []RejectedSample) string {
+	parts := make([]string, len(rejected))
+	for i, r := range rejected {
+		parts[i] = fmt.Sprintf("%d (%s)", r.RunNumber, r.Reason)
+	}
+	return strings.Join(parts, "; ")
+}
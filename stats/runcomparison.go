@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"sort"
+)
+
+// RunComparison pivots one algorithm and input file across run names, with
+// speedups relative to the baseline run
+type RunComparison struct {
+	Algorithm     string             `json:"algorithm"`
+	File          string             `json:"file"`
+	FileSizeBytes int                `json:"file_size_bytes"`
+	Medians       map[string]float64 `json:"medians"`
+	Speedups      map[string]float64 `json:"speedups"`
+}
+
+// CompareRuns pivots median CPU statistics, in the given unit, into
+// algorithm x file rows with one value per run name. A speedup above 1 means
+// the run was faster than the baseline.
+func CompareRuns(stats []CPUStats, unit CPUUnit, baseline string) []RunComparison {
+	rows := make(map[[2]string]*RunComparison)
+	for _, stat := range stats {
+		if stat.Count == 0 {
+			continue
+		}
+		key := [2]string{stat.Algorithm, stat.File}
+		row, ok := rows[key]
+		if !ok {
+			row = &RunComparison{
+				Algorithm:     stat.Algorithm,
+				File:          stat.File,
+				FileSizeBytes: stat.FileSizeBytes,
+				Medians:       make(map[string]float64),
+				Speedups:      make(map[string]float64),
+			}
+			rows[key] = row
+		}
+		row.Medians[stat.RunName] = unit.Convert(stat.Median, stat)
+	}
+
+	var comparisons []RunComparison
+	for _, row := range rows {
+		base, ok := row.Medians[baseline]
+		if ok && base != 0 {
+			for runName, value := range row.Medians {
+				if value == 0 {
+					continue
+				}
+				if unit.Inverse {
+					row.Speedups[runName] = value / base
+				} else {
+					row.Speedups[runName] = base / value
+				}
+			}
+		}
+		comparisons = append(comparisons, *row)
+	}
+
+	sort.Slice(comparisons, func(i, j int) bool {
+		if comparisons[i].Algorithm != comparisons[j].Algorithm {
+			return comparisons[i].Algorithm < comparisons[j].Algorithm
+		}
+		if comparisons[i].FileSizeBytes != comparisons[j].FileSizeBytes {
+			return comparisons[i].FileSizeBytes < comparisons[j].FileSizeBytes
+		}
+		return comparisons[i].File < comparisons[j].File
+	})
+	return comparisons
+}
+
+// RunNames returns the distinct run names in stats in ascending order
+func RunNames(stats []CPUStats) []string {
+	seen := make(map[string]bool)
+	for _, stat := range stats {
+		seen[stat.RunName] = true
+	}
+	return SortedKeys(seen)
+}
@@ -0,0 +1,240 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// PairwiseComparison holds significance tests between two algorithms run on
+// the same input file and machine
+type PairwiseComparison struct {
+	RunName       string  `json:"run_name"`
+	File          string  `json:"file"`
+	FileSizeBytes int     `json:"file_size_bytes"`
+	AlgorithmA    string  `json:"algorithm_a"`
+	AlgorithmB    string  `json:"algorithm_b"`
+	CountA        int     `json:"count_a"`
+	CountB        int     `json:"count_b"`
+	MedianA       float64 `json:"median_a"`
+	MedianB       float64 `json:"median_b"`
+	MannWhitneyU  float64 `json:"mann_whitney_u"`
+	MannWhitneyP  float64 `json:"mann_whitney_p"`
+	WelchT        float64 `json:"welch_t"`
+	WelchDF       float64 `json:"welch_df"`
+	WelchP        float64 `json:"welch_p"`
+	CliffsDelta   float64 `json:"cliffs_delta"`
+	EffectSize    string  `json:"effect_size"`
+	HedgesG       float64 `json:"hedges_g"`
+	Winner        string  `json:"winner"`
+	Verdict       string  `json:"verdict"`
+}
+
+// ComparePairwise tests every pair of algorithms that share a run name and
+// input file. A pair is only declared a winner when the Mann-Whitney p-value
+// is below alpha.
+func ComparePairwise(stats []CPUStats, alpha float64) []PairwiseComparison {
+	groups := make(map[string][]CPUStats)
+	var keys []string
+	for _, stat := range stats {
+		key := stat.RunName + "_" + stat.File
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], stat)
+	}
+	sort.Strings(keys)
+
+	var comparisons []PairwiseComparison
+	for _, key := range keys {
+		group := groups[key]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Algorithm < group[j].Algorithm
+		})
+
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				a, b := group[i], group[j]
+				if len(a.Samples) == 0 || len(b.Samples) == 0 {
+					continue
+				}
+				comparisons = append(comparisons, compareSamples(a, b, alpha))
+			}
+		}
+	}
+	return comparisons
+}
+
+func compareSamples(a, b CPUStats, alpha float64) PairwiseComparison {
+	x := SampleCycles(a.Samples)
+	y := SampleCycles(b.Samples)
+
+	u, uP := MannWhitneyU(x, y)
+	t, df, tP := welchTTest(x, y)
+	delta := cliffsDelta(x, y)
+
+	comparison := PairwiseComparison{
+		RunName:       a.RunName,
+		File:          a.File,
+		FileSizeBytes: a.FileSizeBytes,
+		AlgorithmA:    a.Algorithm,
+		AlgorithmB:    b.Algorithm,
+		CountA:        len(x),
+		CountB:        len(y),
+		MedianA:       a.Median,
+		MedianB:       b.Median,
+		MannWhitneyU:  u,
+		MannWhitneyP:  uP,
+		WelchT:        t,
+		WelchDF:       df,
+		WelchP:        tP,
+		CliffsDelta:   delta,
+		EffectSize:    cliffsDeltaMagnitude(delta),
+		HedgesG:       hedgesG(x, y),
+	}
+
+	switch {
+	case uP >= alpha:
+		comparison.Verdict = "no significant difference"
+	case a.Median < b.Median:
+		comparison.Winner = a.Algorithm
+		comparison.Verdict = fmt.Sprintf("%s faster (%s effect)", a.Algorithm, comparison.EffectSize)
+	default:
+		comparison.Winner = b.Algorithm
+		comparison.Verdict = fmt.Sprintf("%s faster (%s effect)", b.Algorithm, comparison.EffectSize)
+	}
+
+	return comparison
+}
+
+// SampleCycles returns the cycle counts of samples
+func SampleCycles(samples []CPUData) []float64 {
+	values := make([]float64, len(samples))
+	for i, d := range samples {
+		values[i] = float64(d.Cycles)
+	}
+	return values
+}
+
+// MannWhitneyU returns the U statistic for x and its two-sided p-value using
+// the normal approximation with tie and continuity corrections
+func MannWhitneyU(x, y []float64) (float64, float64) {
+	type ranked struct {
+		value float64
+		fromX bool
+	}
+	all := make([]ranked, 0, len(x)+len(y))
+	for _, v := range x {
+		all = append(all, ranked{v, true})
+	}
+	for _, v := range y {
+		all = append(all, ranked{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// Assign average ranks to ties and accumulate the tie correction
+	var rankSumX, tieSum float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				rankSumX += rank
+			}
+		}
+		ties := float64(j - i)
+		tieSum += ties*ties*ties - ties
+		i = j
+	}
+
+	n1 := float64(len(x))
+	n2 := float64(len(y))
+	n := n1 + n2
+	u := rankSumX - n1*(n1+1)/2
+
+	meanU := n1 * n2 / 2
+	varianceU := n1 * n2 / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if varianceU <= 0 {
+		return u, 1
+	}
+
+	diff := math.Abs(u-meanU) - 0.5
+	if diff < 0 {
+		diff = 0
+	}
+	return u, normalTwoSidedP(diff / math.Sqrt(varianceU))
+}
+
+// welchTTest returns the t statistic, Welch-Satterthwaite degrees of freedom
+// and two-sided p-value for the difference in means of x and y. A side with
+// a single sample has no variance estimate, so the test is not run and the
+// p-value is 1.
+func welchTTest(x, y []float64) (float64, float64, float64) {
+	if len(x) < 2 || len(y) < 2 {
+		return 0, 0, 1
+	}
+	n1 := float64(len(x))
+	n2 := float64(len(y))
+	v1 := SampleVariance(x) / n1
+	v2 := SampleVariance(y) / n2
+	diff := Mean(x) - Mean(y)
+
+	if v1+v2 == 0 {
+		// Identical constant samples cannot be told apart; distinct ones can
+		if diff == 0 {
+			return 0, n1 + n2 - 2, 1
+		}
+		return 0, n1 + n2 - 2, 0
+	}
+
+	t := diff / math.Sqrt(v1+v2)
+	df := (v1 + v2) * (v1 + v2) / (v1*v1/(n1-1) + v2*v2/(n2-1))
+	return t, df, studentTTwoSidedP(t, df)
+}
+
+// cliffsDelta returns P(x > y) - P(x < y) over all pairs
+func cliffsDelta(x, y []float64) float64 {
+	var greater, less int
+	for _, a := range x {
+		for _, b := range y {
+			if a > b {
+				greater++
+			} else if a < b {
+				less++
+			}
+		}
+	}
+	return float64(greater-less) / float64(len(x)*len(y))
+}
+
+// cliffsDeltaMagnitude uses the thresholds from Romano et al. (2006)
+func cliffsDeltaMagnitude(delta float64) string {
+	switch d := math.Abs(delta); {
+	case d < 0.147:
+		return "negligible"
+	case d < 0.33:
+		return "small"
+	case d < 0.474:
+		return "medium"
+	default:
+		return "large"
+	}
+}
+
+// hedgesG returns the bias-corrected standardised mean difference of x and y
+func hedgesG(x, y []float64) float64 {
+	n1 := float64(len(x))
+	n2 := float64(len(y))
+	if n1+n2 <= 2 {
+		return 0
+	}
+	pooled := math.Sqrt(((n1-1)*SampleVariance(x) + (n2-1)*SampleVariance(y)) / (n1 + n2 - 2))
+	if pooled == 0 {
+		return 0
+	}
+	correction := 1 - 3/(4*(n1+n2)-9)
+	return (Mean(x) - Mean(y)) / pooled * correction
+}
@@ -0,0 +1,59 @@
+package stats_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+	"github.com/jfkonecn/data-transport-phenomena/stats"
+)
+
+func TestComparePairwiseSingleSampleFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"bubble-sort_i9_01_100.bin.csv": "run_number,cycles,cpu_clock_hz,algorithm,file,file_size_bytes\n" +
+			"1,5000,3000000000,bubble-sort,01_100.bin,100",
+		"merge-sort_i9_01_100.bin.csv": "run_number,cycles,cpu_clock_hz,algorithm,file,file_size_bytes\n" +
+			"1,2000,3000000000,merge-sort,01_100.bin,100\n" +
+			"2,2100,3000000000,merge-sort,01_100.bin,100\n" +
+			"3,1900,3000000000,merge-sort,01_100.bin,100",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cpuStats, err := loader.ProcessCPUData(dir, stats.OutlierPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	comparisons := stats.ComparePairwise(cpuStats, 0.05)
+	if len(comparisons) != 1 {
+		t.Fatalf("got %d comparisons, want 1", len(comparisons))
+	}
+
+	c := comparisons[0]
+	for name, value := range map[string]float64{
+		"WelchT":       c.WelchT,
+		"WelchDF":      c.WelchDF,
+		"WelchP":       c.WelchP,
+		"MannWhitneyU": c.MannWhitneyU,
+		"MannWhitneyP": c.MannWhitneyP,
+		"CliffsDelta":  c.CliffsDelta,
+		"HedgesG":      c.HedgesG,
+	} {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			t.Errorf("%s = %v, want a finite value", name, value)
+		}
+	}
+	if c.WelchP != 1 {
+		t.Errorf("WelchP = %v, want 1 when a side has a single sample", c.WelchP)
+	}
+	if _, err := json.Marshal(comparisons); err != nil {
+		t.Errorf("marshalling comparisons: %v", err)
+	}
+}
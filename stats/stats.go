@@ -0,0 +1,405 @@
+// Package stats holds the benchmark result types and the statistics,
+// diagnostics and models calculated from them.
+package stats
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+)
+
+// CPUData represents a single CPU measurement
+type CPUData struct {
+	RunNumber     int    `json:"run_number"`
+	Cycles        int64  `json:"cycles"`
+	CPUClockHz    int64  `json:"cpu_clock_hz"`
+	Algorithm     string `json:"algorithm"`
+	File          string `json:"file"`
+	FileSizeBytes int    `json:"file_size_bytes"`
+}
+
+// MemoryData represents a single memory allocation/free event
+type MemoryData struct {
+	Alignment           string `json:"alignment"`
+	AllocationType      string `json:"allocation_type"`
+	AllocationSizeBytes int64  `json:"allocation_size_bytes"`
+	Algorithm           string `json:"algorithm"`
+	File                string `json:"file"`
+	FileSizeBytes       int    `json:"file_size_bytes"`
+}
+
+// CPUStats holds aggregated statistics for CPU data
+type CPUStats struct {
+	Algorithm     string           `json:"algorithm"`
+	RunName       string           `json:"run_name"`
+	File          string           `json:"file"`
+	FileSizeBytes int              `json:"file_size_bytes"`
+	Average       float64          `json:"average"`
+	StdDev        float64          `json:"std_dev"`
+	Min           int64            `json:"min"`
+	Max           int64            `json:"max"`
+	Count         int              `json:"count"`
+	Median        float64          `json:"median"`
+	P5            float64          `json:"p5"`
+	P25           float64          `json:"p25"`
+	P75           float64          `json:"p75"`
+	P95           float64          `json:"p95"`
+	P99           float64          `json:"p99"`
+	IQR           float64          `json:"iqr"`
+	MAD           float64          `json:"mad"`
+	CV            float64          `json:"cv"`
+	RawCount      int              `json:"raw_count"`
+	Rejected      []RejectedSample `json:"rejected"`
+	MeanCILow     float64          `json:"mean_ci_low"`
+	MeanCIHigh    float64          `json:"mean_ci_high"`
+	MedianCILow   float64          `json:"median_ci_low"`
+	MedianCIHigh  float64          `json:"median_ci_high"`
+	Samples       []CPUData        `json:"samples"`
+	CPUClockHz    float64          `json:"cpu_clock_hz"`
+}
+
+// Statuses of a memory trace. Empty, truncated and unparseable traces get
+// zero or partial statistics, so they are kept apart from traces of
+// algorithms that never allocate.
+const (
+	MemoryStatusOK            = "ok"
+	MemoryStatusNoAllocations = "no-allocations"
+	MemoryStatusEmptyFile     = "empty-file"
+	MemoryStatusTruncated     = "truncated"
+	MemoryStatusParseError    = "parse-error"
+)
+
+// MemoryStats holds aggregated statistics for memory data
+type MemoryStats struct {
+	Algorithm          string  `json:"algorithm"`
+	RunName            string  `json:"run_name"`
+	File               string  `json:"file"`
+	Status             string  `json:"status"`
+	FileSizeBytes      int     `json:"file_size_bytes"`
+	TotalAllocated     int64   `json:"total_allocated"`
+	TotalFreed         int64   `json:"total_freed"`
+	AverageMemoryUsage float64 `json:"average_memory_usage"`
+	AllocationCount    int     `json:"allocation_count"`
+	FreeCount          int     `json:"free_count"`
+	TotalResized       int64   `json:"total_resized"`
+	ResizeCount        int     `json:"resize_count"`
+	TotalRemapped      int64   `json:"total_remapped"`
+	RemapCount         int     `json:"remap_count"`
+	PeakMemoryUsage    int64   `json:"peak_memory_usage"`
+	PeakEventIndex     int     `json:"peak_event_index"`
+	FinalMemoryUsage   int64   `json:"final_memory_usage"`
+	P50MemoryUsage     float64 `json:"p50_memory_usage"`
+	P90MemoryUsage     float64 `json:"p90_memory_usage"`
+	P95MemoryUsage     float64 `json:"p95_memory_usage"`
+	P99MemoryUsage     float64 `json:"p99_memory_usage"`
+	OverFreedBytes     int64   `json:"over_freed_bytes"`
+	UnmatchedAllocs    int     `json:"unmatched_allocs"`
+	UnmatchedFrees     int     `json:"unmatched_frees"`
+	// Live bytes after each event, kept for charts but not serialised
+	MemorySamples []int64 `json:"-"`
+}
+
+// MemoryIntegrity describes allocation imbalances found in a memory trace
+type MemoryIntegrity struct {
+	Algorithm       string   `json:"algorithm"`
+	RunName         string   `json:"run_name"`
+	File            string   `json:"file"`
+	FileSizeBytes   int      `json:"file_size_bytes"`
+	LiveBytesAtEnd  int64    `json:"live_bytes_at_end"`
+	NetBytes        int64    `json:"net_bytes"`
+	OverFreedBytes  int64    `json:"over_freed_bytes"`
+	UnmatchedAllocs int      `json:"unmatched_allocs"`
+	UnmatchedFrees  int      `json:"unmatched_frees"`
+	CountImbalance  int      `json:"count_imbalance"`
+	Issues          []string `json:"issues"`
+}
+
+// SortCPUStats orders stats by algorithm, run name and input size
+func SortCPUStats(stats []CPUStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Algorithm != stats[j].Algorithm {
+			return stats[i].Algorithm < stats[j].Algorithm
+		}
+		if stats[i].RunName != stats[j].RunName {
+			return stats[i].RunName < stats[j].RunName
+		}
+		return stats[i].FileSizeBytes < stats[j].FileSizeBytes
+	})
+}
+
+// SortMemoryStats orders stats by algorithm, run name and input size
+func SortMemoryStats(stats []MemoryStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Algorithm != stats[j].Algorithm {
+			return stats[i].Algorithm < stats[j].Algorithm
+		}
+		if stats[i].RunName != stats[j].RunName {
+			return stats[i].RunName < stats[j].RunName
+		}
+		return stats[i].FileSizeBytes < stats[j].FileSizeBytes
+	})
+}
+
+// CalculateCPUStats summarises the samples of one algorithm, run name and
+// input file. Without samples only the identifying fields are set, so the
+// caller fills in the file size.
+func CalculateCPUStats(data []CPUData, algorithm, runName, file string) CPUStats {
+	if len(data) == 0 {
+		return CPUStats{Algorithm: algorithm, RunName: runName, File: file}
+	}
+
+	var sum int64
+	var min = data[0].Cycles
+	var max = data[0].Cycles
+	var clockSum float64
+	fileSizeBytes := data[0].FileSizeBytes
+
+	for _, d := range data {
+		sum += d.Cycles
+		clockSum += float64(d.CPUClockHz)
+		if d.Cycles < min {
+			min = d.Cycles
+		}
+		if d.Cycles > max {
+			max = d.Cycles
+		}
+	}
+
+	average := float64(sum) / float64(len(data))
+
+	// Calculate standard deviation
+	var varianceSum float64
+	for _, d := range data {
+		diff := float64(d.Cycles) - average
+		varianceSum += diff * diff
+	}
+	stdDev := math.Sqrt(varianceSum / float64(len(data)))
+
+	// Calculate order statistics, which a single cold run cannot skew
+	sorted := make([]float64, len(data))
+	for i, d := range data {
+		sorted[i] = float64(d.Cycles)
+	}
+	sort.Float64s(sorted)
+	median := Percentile(sorted, 50)
+	p25 := Percentile(sorted, 25)
+	p75 := Percentile(sorted, 75)
+
+	cv := float64(0)
+	if average != 0 {
+		cv = stdDev / average
+	}
+
+	return CPUStats{
+		Algorithm:     algorithm,
+		RunName:       runName,
+		File:          file,
+		FileSizeBytes: fileSizeBytes,
+		Average:       average,
+		StdDev:        stdDev,
+		Min:           min,
+		Max:           max,
+		Count:         len(data),
+		Median:        median,
+		P5:            Percentile(sorted, 5),
+		P25:           p25,
+		P75:           p75,
+		P95:           Percentile(sorted, 95),
+		P99:           Percentile(sorted, 99),
+		IQR:           p75 - p25,
+		MAD:           MedianAbsoluteDeviation(sorted, median),
+		CV:            cv,
+		CPUClockHz:    clockSum / float64(len(data)),
+	}
+}
+
+// CalculateMemoryStats replays the allocation trace of one algorithm, run
+// name and input file
+func CalculateMemoryStats(data []MemoryData, algorithm, runName, file string) MemoryStats {
+	if len(data) == 0 {
+		return MemoryStats{Algorithm: algorithm, RunName: runName, File: file, Status: MemoryStatusNoAllocations}
+	}
+
+	var totalAllocated, totalFreed int64
+	var allocationCount, freeCount int
+	fileSizeBytes := data[0].FileSizeBytes
+
+	var totalResized, totalRemapped int64
+	var resizeCount, remapCount int
+	var overFreedBytes int64
+	var unmatchedFrees int
+
+	// Track current memory usage for average calculation
+	var currentMemory int64
+	var memorySamples []int64
+
+	// The log has no addresses, so live blocks are tracked by size in
+	// allocation order. RESIZE and REMAP rows only carry the new length and
+	// are applied to the most recently allocated live block. The logging
+	// allocator only writes them for calls that succeeded; a failed call
+	// appears as the ALLOC and FREE of the caller's fallback instead. Traces
+	// recorded before it did so count failed attempts as successful.
+	var liveBlocks []int64
+
+	for _, d := range data {
+		switch d.AllocationType {
+		case "ALLOC":
+			totalAllocated += d.AllocationSizeBytes
+			allocationCount++
+			currentMemory += d.AllocationSizeBytes
+			liveBlocks = append(liveBlocks, d.AllocationSizeBytes)
+		case "FREE":
+			totalFreed += d.AllocationSizeBytes
+			freeCount++
+			currentMemory -= d.AllocationSizeBytes
+			if currentMemory < 0 {
+				overFreedBytes -= currentMemory
+				currentMemory = 0 // Can't have negative memory
+			}
+			var matched bool
+			liveBlocks, matched = removeLiveBlock(liveBlocks, d.AllocationSizeBytes)
+			if !matched {
+				unmatchedFrees++
+			}
+		case "RESIZE", "REMAP":
+			if d.AllocationType == "RESIZE" {
+				totalResized += d.AllocationSizeBytes
+				resizeCount++
+			} else {
+				totalRemapped += d.AllocationSizeBytes
+				remapCount++
+			}
+			if len(liveBlocks) == 0 {
+				// Nothing to resize, so treat the new length as a fresh block
+				liveBlocks = append(liveBlocks, d.AllocationSizeBytes)
+				currentMemory += d.AllocationSizeBytes
+			} else {
+				last := len(liveBlocks) - 1
+				currentMemory += d.AllocationSizeBytes - liveBlocks[last]
+				liveBlocks[last] = d.AllocationSizeBytes
+			}
+			if currentMemory < 0 {
+				currentMemory = 0 // Can't have negative memory
+			}
+		default:
+			log.Printf("Warning: unknown allocation type %q for %s_%s_%s", d.AllocationType, algorithm, runName, file)
+			continue
+		}
+		memorySamples = append(memorySamples, currentMemory)
+	}
+
+	// Calculate average and peak memory usage. Each event is one sample, so
+	// the average is weighted by allocator activity rather than wall time.
+	var totalMemory, peakMemory, finalMemory int64
+	var peakEventIndex int
+	for i, mem := range memorySamples {
+		totalMemory += mem
+		if mem > peakMemory {
+			peakMemory = mem
+			peakEventIndex = i + 1
+		}
+	}
+	averageMemoryUsage := float64(0)
+	if len(memorySamples) > 0 {
+		averageMemoryUsage = float64(totalMemory) / float64(len(memorySamples))
+		finalMemory = memorySamples[len(memorySamples)-1]
+	}
+
+	// Percentiles of live memory across all events
+	sortedSamples := make([]float64, len(memorySamples))
+	for i, mem := range memorySamples {
+		sortedSamples[i] = float64(mem)
+	}
+	sort.Float64s(sortedSamples)
+
+	return MemoryStats{
+		Algorithm:          algorithm,
+		RunName:            runName,
+		File:               file,
+		Status:             MemoryStatusOK,
+		FileSizeBytes:      fileSizeBytes,
+		TotalAllocated:     totalAllocated,
+		TotalFreed:         totalFreed,
+		AverageMemoryUsage: averageMemoryUsage,
+		AllocationCount:    allocationCount,
+		FreeCount:          freeCount,
+		TotalResized:       totalResized,
+		ResizeCount:        resizeCount,
+		TotalRemapped:      totalRemapped,
+		RemapCount:         remapCount,
+		PeakMemoryUsage:    peakMemory,
+		PeakEventIndex:     peakEventIndex,
+		FinalMemoryUsage:   finalMemory,
+		P50MemoryUsage:     Percentile(sortedSamples, 50),
+		P90MemoryUsage:     Percentile(sortedSamples, 90),
+		P95MemoryUsage:     Percentile(sortedSamples, 95),
+		P99MemoryUsage:     Percentile(sortedSamples, 99),
+		OverFreedBytes:     overFreedBytes,
+		UnmatchedAllocs:    len(liveBlocks),
+		UnmatchedFrees:     unmatchedFrees,
+		MemorySamples:      memorySamples,
+	}
+}
+
+// Complete reports whether the whole trace was read, so the statistics
+// describe the full run
+func (s MemoryStats) Complete() bool {
+	return s.Status == MemoryStatusOK || s.Status == MemoryStatusNoAllocations
+}
+
+// removeLiveBlock drops the most recent live block of the given size and
+// reports whether one was found
+func removeLiveBlock(liveBlocks []int64, size int64) ([]int64, bool) {
+	for i := len(liveBlocks) - 1; i >= 0; i-- {
+		if liveBlocks[i] == size {
+			return append(liveBlocks[:i], liveBlocks[i+1:]...), true
+		}
+	}
+	return liveBlocks, false
+}
+
+// CheckMemoryIntegrity flags traces that end with live memory, free more
+// than they allocate, or have allocations and frees that do not pair up.
+// Byte balances come from the replayed live-memory model, so blocks grown by
+// RESIZE or REMAP and freed at their new size balance out. Incomplete traces
+// are skipped, as a cut off trace always looks unbalanced.
+func CheckMemoryIntegrity(stats []MemoryStats) []MemoryIntegrity {
+	var results []MemoryIntegrity
+	for _, stat := range stats {
+		if !stat.Complete() {
+			continue
+		}
+		integrity := MemoryIntegrity{
+			Algorithm:       stat.Algorithm,
+			RunName:         stat.RunName,
+			File:            stat.File,
+			FileSizeBytes:   stat.FileSizeBytes,
+			LiveBytesAtEnd:  stat.FinalMemoryUsage,
+			NetBytes:        stat.FinalMemoryUsage - stat.OverFreedBytes,
+			OverFreedBytes:  stat.OverFreedBytes,
+			UnmatchedAllocs: stat.UnmatchedAllocs,
+			UnmatchedFrees:  stat.UnmatchedFrees,
+			CountImbalance:  stat.AllocationCount - stat.FreeCount,
+		}
+
+		if integrity.LiveBytesAtEnd > 0 {
+			integrity.Issues = append(integrity.Issues, fmt.Sprintf("trace ends with %d live bytes", integrity.LiveBytesAtEnd))
+		}
+		if integrity.OverFreedBytes > 0 {
+			integrity.Issues = append(integrity.Issues, fmt.Sprintf("frees exceed allocations by %d bytes", integrity.OverFreedBytes))
+		}
+		if integrity.UnmatchedAllocs > 0 {
+			integrity.Issues = append(integrity.Issues, fmt.Sprintf("%d ALLOC events never freed", integrity.UnmatchedAllocs))
+		}
+		if integrity.UnmatchedFrees > 0 {
+			integrity.Issues = append(integrity.Issues, fmt.Sprintf("%d FREE events without a matching ALLOC", integrity.UnmatchedFrees))
+		}
+		if integrity.CountImbalance != 0 {
+			integrity.Issues = append(integrity.Issues, fmt.Sprintf("%d ALLOC vs %d FREE events", stat.AllocationCount, stat.FreeCount))
+		}
+
+		results = append(results, integrity)
+	}
+	return results
+}
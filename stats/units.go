@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CPUUnit converts a cycle count into another unit using a benchmark's clock
+// estimate and input size
+type CPUUnit struct {
+	Name  string
+	Label string
+	// Inverse units such as throughput get larger as cycles get smaller
+	Inverse bool
+	Convert func(cycles float64, stat CPUStats) float64
+}
+
+// CPUUnits lists the units accepted by LookupCPUUnit
+var CPUUnits = []CPUUnit{
+	{
+		Name:    "cycles",
+		Label:   "Cycles",
+		Convert: func(cycles float64, stat CPUStats) float64 { return cycles },
+	},
+	{
+		Name:    "ns",
+		Label:   "Time (ns)",
+		Convert: func(cycles float64, stat CPUStats) float64 { return cyclesToSeconds(cycles, stat) * 1e9 },
+	},
+	{
+		Name:    "ms",
+		Label:   "Time (ms)",
+		Convert: func(cycles float64, stat CPUStats) float64 { return cyclesToSeconds(cycles, stat) * 1e3 },
+	},
+	{
+		Name:  "cycles-per-byte",
+		Label: "Cycles per Byte",
+		Convert: func(cycles float64, stat CPUStats) float64 {
+			if stat.FileSizeBytes == 0 {
+				return 0
+			}
+			return cycles / float64(stat.FileSizeBytes)
+		},
+	},
+	{
+		Name:    "bytes-per-second",
+		Label:   "Bytes per Second",
+		Inverse: true,
+		Convert: func(cycles float64, stat CPUStats) float64 {
+			seconds := cyclesToSeconds(cycles, stat)
+			if seconds == 0 {
+				return 0
+			}
+			return float64(stat.FileSizeBytes) / seconds
+		},
+	},
+	{
+		Name:  "cycles-per-nlogn",
+		Label: "Cycles per n log n",
+		Convert: func(cycles float64, stat CPUStats) float64 {
+			n := float64(stat.FileSizeBytes)
+			if n <= 1 {
+				return 0
+			}
+			return cycles / (n * math.Log2(n))
+		},
+	},
+}
+
+// cyclesToSeconds uses the clock estimate recorded with the samples. A zero
+// clock, as seen when the ARM cycle counter is not enabled, gives zero.
+func cyclesToSeconds(cycles float64, stat CPUStats) float64 {
+	if stat.CPUClockHz <= 0 {
+		return 0
+	}
+	return cycles / stat.CPUClockHz
+}
+
+// LookupCPUUnit returns the unit with the given name
+func LookupCPUUnit(name string) (CPUUnit, error) {
+	var names []string
+	for _, unit := range CPUUnits {
+		if unit.Name == name {
+			return unit, nil
+		}
+		names = append(names, unit.Name)
+	}
+	return CPUUnit{}, fmt.Errorf("unknown unit %q (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+// ConvertRange converts a low/high cycle pair, keeping low <= high for
+// inverse units
+func (u CPUUnit) ConvertRange(low, high float64, stat CPUStats) (float64, float64) {
+	a, b := u.Convert(low, stat), u.Convert(high, stat)
+	if u.Inverse {
+		return b, a
+	}
+	return a, b
+}
+
+// ConvertSpread converts a spread such as a standard deviation. Only linear
+// units scale a spread the way they scale cycles, so ok is false for inverse
+// units, where the coefficient of variation describes the spread instead.
+func (u CPUUnit) ConvertSpread(spread float64, stat CPUStats) (value float64, ok bool) {
+	if u.Inverse {
+		return 0, false
+	}
+	return u.Convert(spread, stat), true
+}
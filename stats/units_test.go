@@ -0,0 +1,26 @@
+package stats
+
+import "testing"
+
+func TestConvertSpread(t *testing.T) {
+	stat := CPUStats{CPUClockHz: 1e9, FileSizeBytes: 1000}
+	for _, unit := range CPUUnits {
+		got, ok := unit.ConvertSpread(100, stat)
+		if ok == unit.Inverse {
+			t.Errorf("%s: ok = %v for an inverse unit = %v", unit.Name, ok, unit.Inverse)
+			continue
+		}
+		// A linear unit gives the spread of the converted samples
+		if want := unit.Convert(1100, stat) - unit.Convert(1000, stat); ok && !closeTo(got, want) {
+			t.Errorf("%s: spread = %g, want %g", unit.Name, got, want)
+		}
+	}
+}
+
+func closeTo(a, b float64) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= 1e-9*max(1, a, b)
+}
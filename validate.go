@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfkonecn/data-transport-phenomena/loader"
+)
+
+// runValidate implements the validate command and returns the process exit
+// code: 0 when the results are clean, 1 when problems were found and 2 on
+// usage errors
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	inputRoot := fs.String("input", "results/sort", "results `dir`ectory containing cpu/ and memory/")
+	clockDrift := fs.Float64("clock-drift", 0.02, "tolerated relative drift of CPU clock estimates within a run name")
+	dataDir := fs.String("data", "data/sort", "input data `dir`ectory the results are expected to cover (empty skips the coverage check)")
+	strict := fs.Bool("strict", false, "also fail when only warnings are found")
+	verbose := fs.Bool("verbose", false, "also list expected files that are not gaps, such as header-only memory traces")
+	fs.Usage = func() {
+		printCommandUsage(fs.Output(), fs, "validate [options]",
+			"Checks every result file for missing, empty and malformed data, then runs\nthe clock and memory trace diagnostics. With -data, also checks that every\nalgorithm and run name has results for every input file. Exits 1 when errors\nor coverage gaps are found.")
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return flagExitCode(err)
+	}
+
+	issues := loader.Validate(*inputRoot, *clockDrift)
+	printValidationIssues(os.Stdout, issues)
+
+	failed := false
+	for _, issue := range issues {
+		failed = failed || issue.Severity == loader.SeverityError || *strict
+	}
+
+	if *dataDir != "" {
+		coverage, err := loader.CheckCoverage(*inputRoot, *dataDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Println()
+		// Strict mode fails on expected files too, so it lists them
+		printCoverage(os.Stdout, coverage, *verbose || *strict)
+
+		for _, entry := range coverage.Entries {
+			failed = failed || entry.Gap || *strict && entry.Status != loader.CoverageOK
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func printValidationIssues(w io.Writer, issues []loader.Issue) {
+	var errors, warnings int
+	if len(issues) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "Severity\tFile\tIssue")
+		for _, issue := range issues {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", issue.Severity, issue.File, issue.Message)
+			if issue.Severity == loader.SeverityError {
+				errors++
+			} else {
+				warnings++
+			}
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%d errors, %d warnings\n", errors, warnings)
+}
+
+// printCoverage lists the gaps, grouped by status, followed by a count per
+// status. Files that are not ok but not gaps either are only listed when
+// showExpected is set.
+func printCoverage(w io.Writer, coverage loader.Coverage, showExpected bool) {
+	fmt.Fprintf(w, "Coverage: %d algorithms × %d run names × %d data files\n",
+		len(coverage.Algorithms), len(coverage.RunNames), len(coverage.DataFiles))
+
+	counts := make(map[string]int)
+	listed := 0
+	for _, entry := range coverage.Entries {
+		counts[entry.Status]++
+		if entry.Gap || showExpected && entry.Status != loader.CoverageOK {
+			listed++
+		}
+	}
+	if listed > 0 {
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "Status\tKind\tAlgorithm\tRun\tData File\tDetail")
+		for _, status := range loader.CoverageStates[:len(loader.CoverageStates)-1] {
+			for _, entry := range coverage.Entries {
+				if entry.Status != status || !entry.Gap && !showExpected {
+					continue
+				}
+				label := entry.Status
+				if !entry.Gap {
+					label += " (expected)"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", label, entry.Kind, entry.Algorithm, entry.RunName, entry.DataFile, entry.Detail)
+			}
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+
+	var parts []string
+	for _, status := range loader.CoverageStates {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+	}
+	fmt.Fprintf(w, "%d expected files: %s (%d gaps)\n", len(coverage.Entries), strings.Join(parts, ", "), len(coverage.Gaps()))
+}